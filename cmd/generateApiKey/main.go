@@ -0,0 +1,42 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/auth"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/auth/entity"
+	"os"
+	"strings"
+)
+
+func main() {
+	name := flag.String("name", "", "the name of the key holder, recorded in the audit log")
+	roles := flag.String("roles", "", "comma separated roles, e.g. meta-editor or admin")
+	tier := flag.String("tier", "", "the rate limit tier")
+	flag.Parse()
+
+	if *name == "" {
+		fmt.Println("usage: go run main.go -name alice -roles meta-editor [-tier basic]")
+		os.Exit(1)
+	}
+
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	key := hex.EncodeToString(bytes)
+
+	credential := &entity.Credential{Name: *name, KeyHash: auth.HashKey(key), Roles: make([]entity.Role, 0), Tier: *tier}
+	for _, role := range strings.Split(*roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			credential.Roles = append(credential.Roles, entity.Role(role))
+		}
+	}
+
+	output, _ := json.MarshalIndent(credential, "", "  ")
+	fmt.Printf("API key: %s\n\nAdd the credential to the keys file:\n%s\n", key, output)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/hmac"
+	"flag"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// A webhook receiver to test watchlists locally, it verifies and prints each delivery
+func main() {
+	addr := flag.String("addr", ":9000", "the address to listen on")
+	secret := flag.String("secret", "", "the watchlist secret")
+	fail := flag.Int64("fail", 0, "respond 500 to the first deliveries to test retries")
+	tolerance := flag.Duration("tolerance", 5*time.Minute, "the oldest delivery timestamp accepted")
+	flag.Parse()
+
+	if *secret == "" {
+		log.Fatal("usage: go run main.go -secret <watchlist secret> [-addr :9000] [-fail 2]")
+	}
+
+	var received int64
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(watchlist.TimestampHeader), 10, 64)
+		if err != nil || time.Since(time.Unix(timestamp, 0)) > *tolerance {
+			log.Printf("Rejected delivery %s: stale timestamp", r.Header.Get(watchlist.DeliveryHeader))
+			http.Error(w, "stale timestamp", http.StatusUnauthorized)
+			return
+		}
+
+		signature := strings.TrimPrefix(r.Header.Get(watchlist.SignatureHeader), "sha256=")
+		if !hmac.Equal([]byte(signature), []byte(watchlist.Sign(*secret, timestamp, body))) {
+			log.Printf("Rejected delivery %s: invalid signature", r.Header.Get(watchlist.DeliveryHeader))
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		if atomic.AddInt64(&received, 1) <= *fail {
+			log.Printf("Failing delivery %s", r.Header.Get(watchlist.DeliveryHeader))
+			http.Error(w, "failing on purpose", http.StatusInternalServerError)
+			return
+		}
+
+		log.Printf("Delivery %s %s:\n%s", r.Header.Get(watchlist.DeliveryHeader), r.Header.Get(watchlist.EventHeader), body)
+		fmt.Fprint(w, "ok")
+	})
+
+	log.Printf("Listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
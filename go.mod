@@ -8,10 +8,13 @@ require (
 	github.com/gin-contrib/cors v1.3.1
 	github.com/gin-contrib/gzip v0.0.3
 	github.com/gin-gonic/gin v1.7.0
+	github.com/gorilla/websocket v1.4.2
+	github.com/graphql-go/graphql v0.8.1
 	github.com/joho/godotenv v1.3.0
 	github.com/mattn/go-colorable v0.1.8
 	github.com/navcoin/navexplorer-indexer-go/v2 v2.2.10
 	github.com/olivere/elastic/v7 v7.0.21
+	github.com/prometheus/client_golang v1.11.0
 	github.com/sarulabs/di/v2 v2.4.2
 	github.com/sarulabs/dingo/v4 v4.2.0
 	github.com/sirupsen/logrus v1.6.0
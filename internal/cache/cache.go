@@ -4,7 +4,6 @@ import (
 	"crypto/md5"
 	"encoding/gob"
 	"encoding/json"
-	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
 	"io"
@@ -12,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -33,10 +33,6 @@ func (item Item) Expired() bool {
 	return time.Now().UnixNano() > item.Expiration
 }
 
-var (
-	ErrCacheExpired = errors.New("The Cache has expired")
-)
-
 const (
 	// Will retrieve the new data using the callback when retrieved and expired
 	RefreshingExpiration time.Duration = -2
@@ -54,7 +50,17 @@ type Cache struct {
 	// If this is confusing, see the comment at the bottom of New()
 }
 
+// Stats counts cache lookups and refreshes since the cache was created
+type Stats struct {
+	Hits            uint64
+	Misses          uint64
+	Refreshes       uint64
+	RefreshFailures uint64
+}
+
 type cache struct {
+	// The stats are first so they are 64 bit aligned for atomic access
+	stats             Stats
 	defaultExpiration time.Duration
 	items             map[string]Item
 	refreshers        map[string]Refresher
@@ -155,58 +161,73 @@ func (c *cache) Replace(k string, x interface{}, d time.Duration) error {
 	return nil
 }
 
-// Get an item from the cache. Returns the item or nil, and a bool indicating
-// whether the key was found.
+// Get an item from the cache, creating it with the callback when it is not
+// found or has expired. Items stored with RefreshingExpiration keep their
+// callback so they can be rebuilt by Refresh.
 func (c *cache) Get(k string, callback func() (interface{}, error), d time.Duration) (interface{}, error) {
 	c.mu.RLock()
-
 	item, found := c.items[k]
-	if !found {
+	c.mu.RUnlock()
+
+	if !found || item.Expired() {
+		atomic.AddUint64(&c.stats.Misses, 1)
 		log.Debugf("Cache create (%s)", k)
 		x, err := callback()
 		if err == nil {
+			c.mu.Lock()
 			c.set(k, x, d)
-
 			if d == RefreshingExpiration {
 				c.refreshers[k] = Refresher{
 					callback,
 				}
 			}
+			c.mu.Unlock()
 		}
 
-		c.mu.RUnlock()
 		return x, err
 	}
 
-	if item.Expiration > 0 {
-		if time.Now().UnixNano() > item.Expiration {
-			c.mu.RUnlock()
-			return nil, ErrCacheExpired
-		}
-	}
-
+	atomic.AddUint64(&c.stats.Hits, 1)
 	log.Debugf("Cache found (%s)", k)
 
-	c.mu.RUnlock()
 	return item.Object, nil
 }
 
-func (c *Cache) Refresh(network string) {
+// Refresh rebuilds every RefreshingExpiration item for the network using its
+// callback. Items which fail to refresh are removed so the next Get rebuilds
+// them. Returns the number of items refreshed and the number that failed.
+func (c *Cache) Refresh(network string) (refreshed int, failed int) {
+	c.mu.RLock()
+	refreshers := make(map[string]Refresher)
 	for k, r := range c.refreshers {
-		if strings.Index(k, network) != 0 {
-			continue
+		if strings.HasPrefix(k, network+".") {
+			refreshers[k] = r
 		}
+	}
+	c.mu.RUnlock()
 
+	for k, r := range refreshers {
 		x, err := r.Callback()
 		if err != nil {
 			log.WithError(err).Errorf("Cache: Failed to refresh (%s)", k)
+			c.mu.Lock()
+			delete(c.refreshers, k)
+			c.mu.Unlock()
 			c.cache.Delete(k)
+			atomic.AddUint64(&c.stats.RefreshFailures, 1)
+			failed++
 			continue
 		}
 
 		log.Debugf("Cache refresh (%s)", k)
+		c.mu.Lock()
 		c.set(k, x, RefreshingExpiration)
+		c.mu.Unlock()
+		atomic.AddUint64(&c.stats.Refreshes, 1)
+		refreshed++
 	}
+
+	return
 }
 
 // GetWithExpiration returns an item and its expiration time from the cache.
@@ -1126,6 +1147,16 @@ func (c *cache) Items() map[string]Item {
 	return m
 }
 
+// Returns the lookup and refresh counts since the cache was created.
+func (c *cache) Stats() Stats {
+	return Stats{
+		Hits:            atomic.LoadUint64(&c.stats.Hits),
+		Misses:          atomic.LoadUint64(&c.stats.Misses),
+		Refreshes:       atomic.LoadUint64(&c.stats.Refreshes),
+		RefreshFailures: atomic.LoadUint64(&c.stats.RefreshFailures),
+	}
+}
+
 // Returns the number of items in the cache. This may include items that have
 // expired, but have not yet been cleaned up.
 func (c *cache) ItemCount() int {
@@ -1165,6 +1196,19 @@ func stopJanitor(c *Cache) {
 	c.janitor.stop <- true
 }
 
+// Stop stops the janitor goroutine, expired items are no longer deleted until c.DeleteExpired() is called
+func (c *Cache) Stop() {
+	c.mu.Lock()
+	j := c.janitor
+	c.janitor = nil
+	c.mu.Unlock()
+
+	if j != nil {
+		runtime.SetFinalizer(c, nil)
+		j.stop <- true
+	}
+}
+
 func runJanitor(c *cache, ci time.Duration) {
 	j := &janitor{
 		Interval: ci,
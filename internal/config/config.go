@@ -3,12 +3,13 @@ package config
 import (
 	"fmt"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/log"
-	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
+	"net"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
@@ -17,12 +18,19 @@ type Config struct {
 	Debug          bool
 	ElasticSearch  ElasticSearchConfig
 	Index          map[string]string
+	Addresses      map[string]AddressConfig
 	Server         ServerConfig
+	Watcher        WatcherConfig
+	Health         HealthConfig
+	RateLimit      RateLimitConfig
+	Staking        StakingConfig
+	Watchlist      WatchlistConfig
+	DaoEvents      DaoEventsConfig
+	Feed           FeedConfig
 	Legacy         bool
 	Subscribe      bool
 	DefaultNetwork string
-	User           string
-	Password       string
+	Auth           AuthConfig
 }
 
 type ElasticSearchConfig struct {
@@ -34,8 +42,82 @@ type ElasticSearchConfig struct {
 	Password    string
 }
 
+// AddressConfig are a network's base58 address version bytes, as defined in navcoin-core chainparams
+type AddressConfig struct {
+	PubKeyHash    []byte
+	ScriptHash    []byte
+	ColdStaking   []byte
+	ColdStakingV2 []byte
+	Blsct         []byte
+}
+
 type ServerConfig struct {
-	Port int
+	Port            int
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	ExportTimeout   time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type WatcherConfig struct {
+	Interval time.Duration
+}
+
+type HealthConfig struct {
+	MaxBlockAge time.Duration
+	Timeout     time.Duration
+}
+
+// StakingConfig windows are block counts the staking estimate observes rewards over
+type StakingConfig struct {
+	BlockTime time.Duration
+	Windows   []int
+}
+
+// WatchlistConfig retries a failed webhook up to MaxAttempts times, waiting Backoff and then twice as long each retry
+type WatchlistConfig struct {
+	Enabled         bool
+	File            string
+	DeliveryLogPath string
+	Timeout         time.Duration
+	MaxAttempts     int
+	Backoff         time.Duration
+	Workers         int
+	// AllowPrivate lets webhooks reach local and private addresses
+	AllowPrivate bool
+}
+
+// DaoEventsConfig keeps the DAO states and the latest Retention events of each network in File
+type DaoEventsConfig struct {
+	File      string
+	Retention int
+}
+
+// FeedConfig links feeds to BaseUrl, feeds are not available when it is empty
+type FeedConfig struct {
+	BaseUrl string
+}
+
+type AuthConfig struct {
+	KeysFile     string
+	AuditLogPath string
+}
+
+type RateLimitConfig struct {
+	Enabled       bool
+	AnonymousTier string
+	Tiers         map[string]RateLimitTier
+	Keys          map[string]string
+	Costs         map[string]int
+	// TrustedProxies are the proxies whose X-Forwarded-For header is used for the client's IP
+	TrustedProxies []*net.IPNet
+}
+
+// RateLimitTier allows Burst requests at once, refilled at Rate requests per second
+type RateLimitTier struct {
+	Rate  float64
+	Burst int
 }
 
 func Init() {
@@ -65,21 +147,65 @@ func Get() *Config {
 			"testnet": getString("INDEX_TESTNET", "v1"),
 			"mainnet": getString("INDEX_MAINNET", "v2"),
 		},
+		Addresses: map[string]AddressConfig{
+			"devnet":  getAddressConfig("ADDRESS_VERSIONS_DEVNET", "pubkeyhash=111,scripthash=196,coldstaking=8,coldstakingv2=32,blsct=84:37"),
+			"testnet": getAddressConfig("ADDRESS_VERSIONS_TESTNET", "pubkeyhash=111,scripthash=196,coldstaking=8,coldstakingv2=32,blsct=84:37"),
+			"mainnet": getAddressConfig("ADDRESS_VERSIONS_MAINNET", "pubkeyhash=53,scripthash=85,coldstaking=21,coldstakingv2=36,blsct=73:33"),
+		},
 		Server: ServerConfig{
-			Port: getInt("PORT", 8080),
+			Port:            getInt("PORT", 8080),
+			ReadTimeout:     getDuration("SERVER_READ_TIMEOUT", 15*time.Second),
+			WriteTimeout:    getDuration("SERVER_WRITE_TIMEOUT", 60*time.Second),
+			ExportTimeout:   getDuration("SERVER_EXPORT_TIMEOUT", 30*time.Minute),
+			IdleTimeout:     getDuration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			ShutdownTimeout: getDuration("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
+		},
+		Watcher: WatcherConfig{
+			Interval: getDuration("WATCHER_INTERVAL", 5*time.Second),
+		},
+		Health: HealthConfig{
+			MaxBlockAge: getDuration("HEALTH_MAX_BLOCK_AGE", 10*time.Minute),
+			Timeout:     getDuration("HEALTH_TIMEOUT", 5*time.Second),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:       getBool("RATE_LIMIT_ENABLED", false),
+			AnonymousTier: getString("RATE_LIMIT_ANONYMOUS_TIER", "anonymous"),
+			Tiers:         getRateLimitTiers("RATE_LIMIT_TIERS", "anonymous=2:60,basic=10:300"),
+			Keys:          getMap("RATE_LIMIT_KEYS", ""),
+			Costs: getCosts(
+				"RATE_LIMIT_COSTS",
+				"/staking/blocks=20,/staking/rewards=5,/staking/estimate=20,/staking/leaderboard=20,/distribution/wealth=20,/address/:hash/history/export=20,/address/:hash/staking=5,/address/:hash/staking/report=20,/graphql=5,/health/live=0,/health/ready=0,/metrics=0",
+			),
+			TrustedProxies: getNetworks("RATE_LIMIT_TRUSTED_PROXIES", ""),
+		},
+		Staking: StakingConfig{
+			BlockTime: getDuration("STAKING_BLOCK_TIME", 30*time.Second),
+			Windows:   getInts("STAKING_ESTIMATE_WINDOWS", "2880,20160,86400"),
+		},
+		Watchlist: WatchlistConfig{
+			Enabled:         getBool("WATCHLIST_ENABLED", false),
+			File:            getString("WATCHLIST_FILE", "watchlists.json"),
+			DeliveryLogPath: getString("WEBHOOK_LOG_PATH", "/app/logs/webhook.log"),
+			Timeout:         getDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxAttempts:     getInt("WEBHOOK_MAX_ATTEMPTS", 6),
+			Backoff:         getDuration("WEBHOOK_BACKOFF", 30*time.Second),
+			Workers:         getInt("WEBHOOK_WORKERS", 4),
+			AllowPrivate:    getBool("WEBHOOK_ALLOW_PRIVATE", false),
+		},
+		DaoEvents: DaoEventsConfig{
+			File:      getString("DAO_EVENTS_FILE", "dao_events.json"),
+			Retention: getInt("DAO_EVENTS_RETENTION", 10000),
+		},
+		Feed: FeedConfig{
+			BaseUrl: strings.TrimSuffix(getString("FEED_BASE_URL", ""), "/"),
 		},
 		Legacy:    getBool("LEGACY", true),
 		Subscribe: getBool("SUBSCRIBE", false),
 		DefaultNetwork: getString("DEFAULT_NETWORK", "mainnet"),
-		User:           getString("AUTH_USER", "user"),
-		Password:       getString("AUTH_PASSWORD", "password"),
-	}
-}
-
-func Account() gin.Accounts {
-	config := Get()
-	return gin.Accounts{
-		config.User: config.Password,
+		Auth: AuthConfig{
+			KeysFile:     getString("AUTH_KEYS_FILE", "keys.json"),
+			AuditLogPath: getString("AUDIT_LOG_PATH", "/app/logs/audit.log"),
+		},
 	}
 }
 
@@ -100,6 +226,15 @@ func getInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	valStr := getString(key, "")
+	if val, err := time.ParseDuration(valStr); err == nil && val > 0 {
+		return val
+	}
+
+	return defaultValue
+}
+
 func getBool(key string, defaultValue bool) bool {
 	valStr := getString(key, "")
 	if val, err := strconv.ParseBool(valStr); err == nil {
@@ -117,3 +252,121 @@ func getSlice(key string, defaultVal []string, sep string) []string {
 
 	return strings.Split(valStr, sep)
 }
+
+// getInts parses a comma separated list of positive integers
+func getInts(key string, defaultValue string) []int {
+	values := make([]int, 0)
+	for _, valStr := range strings.Split(getString(key, defaultValue), ",") {
+		val, err := strconv.Atoi(strings.TrimSpace(valStr))
+		if err != nil || val <= 0 {
+			zap.S().Warnf("Config: Invalid %s value %s", key, valStr)
+			continue
+		}
+		values = append(values, val)
+	}
+
+	return values
+}
+
+// getMap parses a comma separated list of key=value pairs
+func getMap(key string, defaultValue string) map[string]string {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(getString(key, defaultValue), ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) == 2 && kv[0] != "" {
+			values[kv[0]] = kv[1]
+		}
+	}
+
+	return values
+}
+
+// getRateLimitTiers parses a comma separated list of name=rate:burst tiers
+func getRateLimitTiers(key string, defaultValue string) map[string]RateLimitTier {
+	tiers := make(map[string]RateLimitTier)
+	for name, value := range getMap(key, defaultValue) {
+		parts := strings.Split(value, ":")
+		if len(parts) != 2 {
+			zap.S().Warnf("Config: Invalid rate limit tier %s", name)
+			continue
+		}
+
+		rate, rateErr := strconv.ParseFloat(parts[0], 64)
+		burst, burstErr := strconv.Atoi(parts[1])
+		if rateErr != nil || burstErr != nil || rate <= 0 || burst <= 0 {
+			zap.S().Warnf("Config: Invalid rate limit tier %s", name)
+			continue
+		}
+		tiers[name] = RateLimitTier{Rate: rate, Burst: burst}
+	}
+
+	return tiers
+}
+
+// getAddressConfig parses a comma separated list of type=version pairs, where a version of several bytes is colon separated
+func getAddressConfig(key string, defaultValue string) AddressConfig {
+	versions := make(map[string][]byte)
+	for name, value := range getMap(key, defaultValue) {
+		version := make([]byte, 0)
+		for _, part := range strings.Split(value, ":") {
+			b, err := strconv.ParseUint(strings.TrimSpace(part), 10, 8)
+			if err != nil {
+				zap.S().Warnf("Config: Invalid %s version for %s", key, name)
+				version = nil
+				break
+			}
+			version = append(version, byte(b))
+		}
+		versions[name] = version
+	}
+
+	return AddressConfig{
+		PubKeyHash:    versions["pubkeyhash"],
+		ScriptHash:    versions["scripthash"],
+		ColdStaking:   versions["coldstaking"],
+		ColdStakingV2: versions["coldstakingv2"],
+		Blsct:         versions["blsct"],
+	}
+}
+
+// getNetworks parses a comma separated list of CIDRs, where an IP is a network of one address
+func getNetworks(key string, defaultValue string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0)
+	for _, value := range strings.Split(getString(key, defaultValue), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			zap.S().Warnf("Config: Invalid %s value %s", key, value)
+			continue
+		}
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+// getCosts parses a comma separated list of route=cost pairs
+func getCosts(key string, defaultValue string) map[string]int {
+	costs := make(map[string]int)
+	for route, value := range getMap(key, defaultValue) {
+		cost, err := strconv.Atoi(value)
+		if err != nil || cost < 0 {
+			zap.S().Warnf("Config: Invalid rate limit cost for %s", route)
+			continue
+		}
+		costs[route] = cost
+	}
+
+	return costs
+}
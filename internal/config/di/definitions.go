@@ -2,14 +2,25 @@ package di
 
 import (
 	"github.com/navcoin/navexplorer-api-go/v2/internal/cache"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/elastic_cache"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/audit"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/auth"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/consensus"
+	daoEvent "github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/event"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/esplora"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/feed"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/health"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/insight"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/search"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/softfork"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/stream"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist"
 	"github.com/sarulabs/dingo/v4"
 	log "github.com/sirupsen/logrus"
 	"time"
@@ -46,14 +57,32 @@ var Definitions = []dingo.Def{
 			addressHistoryRepository repository.AddressHistoryRepository,
 			blockRepository repository.BlockRepository,
 			blockTransactionRepository repository.BlockTransactionRepository,
+			cache *cache.Cache,
 		) (address.Service, error) {
-			return address.NewAddressService(addressRepository, addressHistoryRepository, blockRepository, blockTransactionRepository), nil
+			return address.NewAddressService(addressRepository, addressHistoryRepository, blockRepository, blockTransactionRepository, cache), nil
+		},
+	},
+	{
+		Name: "auth.service",
+		Build: func() (auth.Service, error) {
+			credentials, err := auth.LoadCredentials(config.Get().Auth.KeysFile)
+			if err != nil {
+				log.WithError(err).Fatal("Failed to load the API keys")
+			}
+
+			return auth.NewAuthService(credentials), nil
+		},
+	},
+	{
+		Name: "audit.log",
+		Build: func() (audit.Log, error) {
+			return audit.NewFileLog(config.Get().Auth.AuditLogPath), nil
 		},
 	},
 	{
 		Name: "block.repo",
 		Build: func(elastic *elastic_cache.Index, cache *cache.Cache) (repository.BlockRepository, error) {
-			return repository.NewBlockRepository(elastic), nil
+			return repository.NewCachingBlockRepository(repository.NewBlockRepository(elastic), cache), nil
 		},
 	},
 	{
@@ -68,6 +97,44 @@ var Definitions = []dingo.Def{
 			return block.NewBlockService(blockRepository, blockTransactionRepository), nil
 		},
 	},
+	{
+		Name: "block.watcher",
+		Build: func(elastic *elastic_cache.Index, cache *cache.Cache) (block.Watcher, error) {
+			// The watcher must see the indexed best block rather than the cached one
+			watcher := block.NewWatcher(repository.NewBlockRepository(elastic), config.Get().Watcher.Interval)
+			watcher.OnBestBlock(block.RefreshCache(cache))
+
+			return watcher, nil
+		},
+	},
+	{
+		Name: "stream.service",
+		Build: func(watcher block.Watcher, blockTransactionRepository repository.BlockTransactionRepository) (stream.Service, error) {
+			return stream.NewStreamService(watcher, blockTransactionRepository), nil
+		},
+	},
+	{
+		Name: "watchlist.service",
+		Build: func(addressHistoryRepo repository.AddressHistoryRepository, watcher block.Watcher, daoEventService daoEvent.Service) (watchlist.Service, error) {
+			store, err := watchlist.NewFileStore(config.Get().Watchlist.File)
+			if err != nil {
+				log.WithError(err).Fatal("Failed to load the watchlists")
+			}
+
+			return watchlist.NewWatchlistService(
+				store,
+				addressHistoryRepo,
+				watcher,
+				daoEventService,
+				config.Get().Watchlist.Timeout,
+				config.Get().Watchlist.MaxAttempts,
+				config.Get().Watchlist.Backoff,
+				config.Get().Watchlist.Workers,
+				config.Get().Watchlist.DeliveryLogPath,
+				config.Get().Watchlist.AllowPrivate,
+			), nil
+		},
+	},
 	{
 		Name: "dao.proposal.repo",
 		Build: func(elastic *elastic_cache.Index) (repository.DaoProposalRepository, error) {
@@ -110,6 +177,18 @@ var Definitions = []dingo.Def{
 			return softfork.NewSoftForkService(blockRepository, softforkRepository), nil
 		},
 	},
+	{
+		Name: "search.repo",
+		Build: func(elastic *elastic_cache.Index) (repository.SearchRepository, error) {
+			return repository.NewSearchRepository(elastic), nil
+		},
+	},
+	{
+		Name: "search.service",
+		Build: func(searchRepository repository.SearchRepository) (search.Service, error) {
+			return search.NewSearchService(searchRepository), nil
+		},
+	},
 	{
 		Name: "dao.service",
 		Build: func(
@@ -131,10 +210,75 @@ var Definitions = []dingo.Def{
 			return consensus.NewConsensusService(consensusRepo), nil
 		},
 	},
+	{
+		Name: "dao.event.service",
+		Build: func(
+			proposalRepo repository.DaoProposalRepository,
+			paymentRequestRepo repository.DaoPaymentRequestRepository,
+			consultationRepo repository.DaoConsultationRepository,
+			watcher block.Watcher,
+		) (daoEvent.Service, error) {
+			service, err := daoEvent.NewDaoEventService(proposalRepo, paymentRequestRepo, consultationRepo, watcher, config.Get().DaoEvents.File, config.Get().DaoEvents.Retention)
+			if err != nil {
+				log.WithError(err).Fatal("Failed to load the DAO events")
+			}
+
+			return service, nil
+		},
+	},
+	{
+		Name: "feed.service",
+		Build: func(blockService block.Service, daoService dao.Service, blockRepo repository.BlockRepository) (feed.Service, error) {
+			return feed.NewFeedService(blockService, daoService, blockRepo), nil
+		},
+	},
+	{
+		Name: "insight.service",
+		Build: func(addressService address.Service, blockService block.Service) (insight.Service, error) {
+			return insight.NewInsightService(addressService, blockService), nil
+		},
+	},
+	{
+		Name: "esplora.service",
+		Build: func(
+			addressService address.Service,
+			blockService block.Service,
+			addressHistoryRepo repository.AddressHistoryRepository,
+			blockTransactionRepo repository.BlockTransactionRepository,
+		) (esplora.Service, error) {
+			return esplora.NewEsploraService(addressService, blockService, addressHistoryRepo, blockTransactionRepo), nil
+		},
+	},
 	{
 		Name: "staking.service",
-		Build: func(addressHistoryRepo repository.AddressHistoryRepository) (service.StakingService, error) {
-			return service.NewStakingService(addressHistoryRepo), nil
+		Build: func(
+			addressHistoryRepo repository.AddressHistoryRepository,
+			blockRepo repository.BlockRepository,
+			addressService address.Service,
+			blockService block.Service,
+			consensusService consensus.Service,
+			cache *cache.Cache,
+		) (service.StakingService, error) {
+			return service.NewStakingService(
+				addressHistoryRepo,
+				blockRepo,
+				addressService,
+				blockService,
+				consensusService,
+				cache,
+				config.Get().Staking.BlockTime,
+				config.Get().Staking.Windows,
+			), nil
+		},
+	},
+	{
+		Name: "health.service",
+		Build: func(elastic *elastic_cache.Index) (health.Service, error) {
+			return health.NewHealthService(
+				repository.NewHealthRepository(elastic),
+				config.Get().Health.MaxBlockAge,
+				config.Get().Health.Timeout,
+			), nil
 		},
 	},
 	{
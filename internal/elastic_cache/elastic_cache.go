@@ -2,8 +2,10 @@ package elastic_cache
 
 import (
 	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/metrics"
 	"github.com/olivere/elastic/v7"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 )
@@ -17,6 +19,7 @@ func New() (*Index, error) {
 		elastic.SetURL(strings.Join(config.Get().ElasticSearch.Hosts, ",")),
 		elastic.SetSniff(config.Get().ElasticSearch.Sniff),
 		elastic.SetHealthcheck(config.Get().ElasticSearch.HealthCheck),
+		elastic.SetHttpClient(&http.Client{Transport: metrics.NewElasticTransport(http.DefaultTransport)}),
 	}
 
 	if config.Get().ElasticSearch.Username != "" {
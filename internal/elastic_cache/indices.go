@@ -21,6 +21,21 @@ var (
 	SoftForkIndex         Indices = "softfork"
 )
 
+// AllIndices are the indices the API queries for each network
+var AllIndices = []Indices{
+	AddressIndex,
+	AddressHistoryIndex,
+	BlockIndex,
+	BlockTransactionIndex,
+	ConsensusIndex,
+	ProposalIndex,
+	DaoVoteIndex,
+	DaoConsultationIndex,
+	PaymentRequestIndex,
+	SignalIndex,
+	SoftForkIndex,
+}
+
 func (i *Indices) Get(network network.Network) string {
 	return fmt.Sprintf("%s.%s", network.String(), string(*i))
 }
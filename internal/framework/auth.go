@@ -0,0 +1,56 @@
+package framework
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/audit"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/auth"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/auth/entity"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+const (
+	CREDENTIAL  string = "credential"
+	AUDIT_TRAIL string = "audit_trail"
+)
+
+// RequireRole only allows requests with an API key holding the role
+func RequireRole(authService auth.Service, role entity.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credential, err := authService.Authenticate(ApiKey(c))
+		if err != nil {
+			c.Header("WWW-Authenticate", "ApiKey")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": err.Error(), "status": http.StatusUnauthorized})
+			return
+		}
+
+		if !credential.HasRole(role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "API key does not have the " + string(role) + " role", "status": http.StatusForbidden})
+			return
+		}
+
+		c.Set(CREDENTIAL, credential)
+	}
+}
+
+// Audit records the audit trail of a request once it is handled, with the key holder and the response status
+func Audit(auditLog audit.Log) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		trail := audit.NewTrail()
+		c.Set(AUDIT_TRAIL, trail)
+
+		c.Next()
+
+		actor := ""
+		if credential, ok := c.Get(CREDENTIAL); ok {
+			actor = credential.(*entity.Credential).Name
+		}
+		for _, entry := range trail.Entries() {
+			entry.Actor = actor
+			entry.Status = c.Writer.Status()
+			if err := auditLog.Record(entry); err != nil {
+				log.WithError(err).WithFields(log.Fields{"actor": actor, "address": entry.Address, "key": entry.Key}).Error("Failed to record the audit log")
+			}
+		}
+	}
+}
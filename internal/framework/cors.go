@@ -8,8 +8,8 @@ import (
 func Cors() gin.HandlerFunc {
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
-	config.AllowHeaders = append(config.AllowHeaders, "Network")
-	config.ExposeHeaders = append(config.AllowHeaders, "X-Network", "X-Pagination")
+	config.AllowHeaders = append(config.AllowHeaders, "Network", "X-API-Key")
+	config.ExposeHeaders = append(config.AllowHeaders, "X-Network", "X-Pagination", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After")
 
 	return cors.New(config)
 }
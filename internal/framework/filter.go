@@ -5,10 +5,108 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
+	"net/http"
 	"strings"
+	"sync"
 )
 
-var ErrInvalidFilterValue = errors.New("The filter parameter is an invalid format")
+var (
+	ErrInvalidFilterValue        = errors.New("The filter parameter is an invalid format")
+	ErrFilterFieldUnsupported    = errors.New("The filter field is not supported")
+	ErrFilterOperatorUnsupported = errors.New("The filter operator is not supported for the field")
+	ErrFilterNegationUnsupported = errors.New("The filter field cannot be negated")
+)
+
+func IsFilterError(err error) bool {
+	return errors.Is(err, ErrInvalidFilterValue) ||
+		errors.Is(err, ErrFilterFieldUnsupported) ||
+		errors.Is(err, ErrFilterOperatorUnsupported) ||
+		errors.Is(err, ErrFilterNegationUnsupported)
+}
+
+// FilterField declares the operators a field may be filtered with, and whether the filter may be negated
+type FilterField struct {
+	Operators []FilterOperator
+	Negatable bool
+}
+
+var (
+	filterFields   = make(map[string]map[string]FilterField)
+	filterFieldsMu sync.RWMutex
+)
+
+// RegisterFilterField declares a field which may be filtered on for the resource.
+// A field declared twice for a resource supports the operators of each declaration.
+func RegisterFilterField(resource string, name string, field FilterField) {
+	filterFieldsMu.Lock()
+	defer filterFieldsMu.Unlock()
+
+	if filterFields[resource] == nil {
+		filterFields[resource] = make(map[string]FilterField)
+	}
+
+	declared := filterFields[resource][name]
+	for _, operator := range field.Operators {
+		if !declared.supports(operator) {
+			declared.Operators = append(declared.Operators, operator)
+		}
+	}
+	declared.Negatable = declared.Negatable || field.Negatable
+	filterFields[resource][name] = declared
+}
+
+func (ff FilterField) supports(operator FilterOperator) bool {
+	for _, supported := range ff.Operators {
+		if supported == operator {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckFilters rejects the options for a field which is not declared for the resource, or with an operator or negation it does not support
+func CheckFilters(resource string, f Filters) error {
+	for _, option := range f.Options() {
+		if err := checkFilterOption(resource, option); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkFilterOption(resource string, option FilterOption) error {
+	filterFieldsMu.RLock()
+	field, ok := filterFields[resource][option.Field()]
+	filterFieldsMu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrFilterFieldUnsupported, option.Field())
+	}
+	if !field.supports(option.Operator()) {
+		return fmt.Errorf("%w: %s %s", ErrFilterOperatorUnsupported, option.Field(), option.Operator())
+	}
+	if option.Negated() && !field.Negatable {
+		return fmt.Errorf("%w: %s", ErrFilterNegationUnsupported, option.Field())
+	}
+
+	return nil
+}
+
+// Filterable rejects the filters of the request which are not declared for the resource of the route
+func Filterable(resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rr, ok := c.Get(REST)
+		if !ok {
+			return
+		}
+
+		if err := CheckFilters(resource, rr.(RestRequest).Filters()); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error(), "status": http.StatusBadRequest})
+		}
+	}
+}
 
 type Filters interface {
 	Options() FilterOptions
@@ -20,7 +118,7 @@ type filters struct {
 	options FilterOptions
 }
 
-func newFilters(options FilterOptions) Filters {
+func NewFilters(options FilterOptions) Filters {
 	for _, o := range options {
 		log.Infof("%s = %s", o.Field(), o.Values())
 	}
@@ -32,24 +130,82 @@ func newFilters(options FilterOptions) Filters {
 func newFiltersFromContext(c *gin.Context) (Filters, error) {
 	sortQuery, exists := c.GetQuery("filters")
 	if exists == false {
-		return newFilters(nil), nil
+		return NewFilters(nil), nil
 	}
 
+	return ParseFilters(sortQuery)
+}
+
+// ParseFilters parses a comma separated list of filter expressions, which CheckFilters validates for a resource
+func ParseFilters(value string) (Filters, error) {
 	options := make([]FilterOption, 0)
-	for _, param := range strings.Split(sortQuery, ",") {
-		optionArray := strings.Split(param, ":")
-		if len(optionArray) != 2 {
-			return newFilters(nil), ErrInvalidFilterValue
+	for _, param := range strings.Split(value, ",") {
+		option, err := parseFilterOption(param)
+		if err != nil {
+			return NewFilters(nil), err
+		}
+		options = append(options, option)
+	}
+
+	return NewFilters(options), nil
+}
+
+// parseFilterOption parses a single filter expression:
+//
+//	field:v1|v2   field equals any of the values
+//	field!=v      field does not equal the value
+//	field>=v      field is compared to the value with >, >=, < or <=
+//	field:*       field exists
+//	!expression   negates any of the above
+func parseFilterOption(param string) (FilterOption, error) {
+	negated := strings.HasPrefix(param, "!")
+	param = strings.TrimPrefix(param, "!")
+
+	i := strings.IndexAny(param, ":<>!=")
+	if i <= 0 || !validFilterField(param[:i]) {
+		return nil, ErrInvalidFilterValue
+	}
+	field, expression := param[:i], param[i:]
+
+	for _, operator := range filterOperatorTokens {
+		if !strings.HasPrefix(expression, operator.token) {
+			continue
+		}
+
+		value := strings.TrimPrefix(expression, operator.token)
+		if value == "" {
+			return nil, ErrInvalidFilterValue
+		}
+
+		switch operator.operator {
+		case FilterEquals:
+			if operator.token == "!=" {
+				negated = !negated
+			} else if value == "*" {
+				return NewFilterExpression(field, FilterExists, negated, nil), nil
+			}
+
+			values := make([]interface{}, 0)
+			for _, v := range strings.Split(value, "|") {
+				values = append(values, v)
+			}
+			return NewFilterExpression(field, FilterEquals, negated, values), nil
+		default:
+			return NewFilterExpression(field, operator.operator, negated, []interface{}{value}), nil
 		}
+	}
+
+	return nil, ErrInvalidFilterValue
+}
 
-		values := make([]interface{}, 0)
-		for _, v := range strings.Split(optionArray[1], "|") {
-			values = append(values, v)
+func validFilterField(field string) bool {
+	for _, r := range field {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.') {
+			return false
 		}
-		options = append(options, NewFilterOption(optionArray[0], values))
 	}
 
-	return newFilters(options), nil
+	return true
 }
 
 func (f *filters) Options() FilterOptions {
@@ -85,21 +241,65 @@ func (fos *FilterOptions) Get(field string) (FilterOption, error) {
 	return nil, errors.New(fmt.Sprintf("Filter Option %s not found", field))
 }
 
+type FilterOperator string
+
+var (
+	FilterEquals             FilterOperator = "eq"
+	FilterGreaterThan        FilterOperator = "gt"
+	FilterGreaterThanOrEqual FilterOperator = "gte"
+	FilterLessThan           FilterOperator = "lt"
+	FilterLessThanOrEqual    FilterOperator = "lte"
+	FilterExists             FilterOperator = "exists"
+)
+
+// FilterOperators are all of the operators, for fields compared with any of them
+var FilterOperators = []FilterOperator{
+	FilterEquals,
+	FilterGreaterThan,
+	FilterGreaterThanOrEqual,
+	FilterLessThan,
+	FilterLessThanOrEqual,
+	FilterExists,
+}
+
+// Two character tokens are listed first so ">=" is not read as ">"
+var filterOperatorTokens = []struct {
+	token    string
+	operator FilterOperator
+}{
+	{">=", FilterGreaterThanOrEqual},
+	{"<=", FilterLessThanOrEqual},
+	{"!=", FilterEquals},
+	{">", FilterGreaterThan},
+	{"<", FilterLessThan},
+	{":", FilterEquals},
+}
+
 type FilterOption interface {
 	Field() string
+	Operator() FilterOperator
+	Negated() bool
 	Values() []interface{}
 	SingleValue() interface{}
 }
 
 type filterOption struct {
-	field  string
-	values []interface{}
+	field    string
+	operator FilterOperator
+	negated  bool
+	values   []interface{}
 }
 
 func NewFilterOption(field string, values []interface{}) FilterOption {
+	return NewFilterExpression(field, FilterEquals, false, values)
+}
+
+func NewFilterExpression(field string, operator FilterOperator, negated bool, values []interface{}) FilterOption {
 	return &filterOption{
-		field:  field,
-		values: values,
+		field:    field,
+		operator: operator,
+		negated:  negated,
+		values:   values,
 	}
 }
 
@@ -107,10 +307,23 @@ func (fo *filterOption) Field() string {
 	return fo.field
 }
 
+func (fo *filterOption) Operator() FilterOperator {
+	return fo.operator
+}
+
+func (fo *filterOption) Negated() bool {
+	return fo.negated
+}
+
 func (fo *filterOption) Values() []interface{} {
 	return fo.values
 }
 
+// SingleValue is the first value, or nil for an exists option which has none
 func (fo *filterOption) SingleValue() interface{} {
+	if len(fo.values) == 0 {
+		return nil
+	}
+
 	return fo.values[0]
 }
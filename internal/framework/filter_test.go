@@ -0,0 +1,155 @@
+package framework
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	RegisterFilterField("test_block", "height", FilterField{Operators: FilterOperators, Negatable: true})
+	RegisterFilterField("test_block", "type", FilterField{Operators: []FilterOperator{FilterEquals}})
+	RegisterFilterField("test_history", "is_stake", FilterField{Operators: FilterOperators, Negatable: true})
+	RegisterFilterField("test_history", "type", FilterField{Operators: []FilterOperator{FilterEquals}})
+	RegisterFilterField("test_history", "type", FilterField{Operators: []FilterOperator{FilterExists}, Negatable: true})
+}
+
+func TestParseFilterOption(t *testing.T) {
+	tests := []struct {
+		param    string
+		field    string
+		operator FilterOperator
+		negated  bool
+		values   []interface{}
+		err      error
+	}{
+		{param: "height:100", field: "height", operator: FilterEquals, values: []interface{}{"100"}},
+		{param: "height:100|200", field: "height", operator: FilterEquals, values: []interface{}{"100", "200"}},
+		{param: "fees!=0", field: "fees", operator: FilterEquals, negated: true, values: []interface{}{"0"}},
+		{param: "height>1000", field: "height", operator: FilterGreaterThan, values: []interface{}{"1000"}},
+		{param: "height>=1000", field: "height", operator: FilterGreaterThanOrEqual, values: []interface{}{"1000"}},
+		{param: "height<1000", field: "height", operator: FilterLessThan, values: []interface{}{"1000"}},
+		{param: "height<=1000", field: "height", operator: FilterLessThanOrEqual, values: []interface{}{"1000"}},
+		{param: "time<2021-01-01T00:00:00Z", field: "time", operator: FilterLessThan, values: []interface{}{"2021-01-01T00:00:00Z"}},
+		{param: "changes.spendable:*", field: "changes.spendable", operator: FilterExists},
+		{param: "!is_stake:true", field: "is_stake", operator: FilterEquals, negated: true, values: []interface{}{"true"}},
+		{param: "!fees!=0", field: "fees", operator: FilterEquals, values: []interface{}{"0"}},
+		{param: "!height>=1000", field: "height", operator: FilterGreaterThanOrEqual, negated: true, values: []interface{}{"1000"}},
+		{param: "!meta.label:*", field: "meta.label", operator: FilterExists, negated: true},
+		{param: "height", err: ErrInvalidFilterValue},
+		{param: "height:", err: ErrInvalidFilterValue},
+		{param: "height>=", err: ErrInvalidFilterValue},
+		{param: ":100", err: ErrInvalidFilterValue},
+		{param: "", err: ErrInvalidFilterValue},
+		{param: "hei ght:100", err: ErrInvalidFilterValue},
+		{param: "height=100", err: ErrInvalidFilterValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.param, func(t *testing.T) {
+			option, err := parseFilterOption(tt.param)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			if option.Field() != tt.field {
+				t.Errorf("Field() = %q, want %q", option.Field(), tt.field)
+			}
+			if option.Operator() != tt.operator {
+				t.Errorf("Operator() = %q, want %q", option.Operator(), tt.operator)
+			}
+			if option.Negated() != tt.negated {
+				t.Errorf("Negated() = %v, want %v", option.Negated(), tt.negated)
+			}
+			if !reflect.DeepEqual(option.Values(), tt.values) {
+				t.Errorf("Values() = %v, want %v", option.Values(), tt.values)
+			}
+		})
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	f, err := ParseFilters("height>=1000,!type:coinbase|stake")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Options()) != 2 {
+		t.Fatalf("Options() = %d, want 2", len(f.Options()))
+	}
+
+	if _, err := ParseFilters("height>=1000,fees"); err != ErrInvalidFilterValue {
+		t.Errorf("err = %v, want %v", err, ErrInvalidFilterValue)
+	}
+}
+
+func TestCheckFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		filters  string
+		err      error
+	}{
+		{name: "declared field", resource: "test_block", filters: "height>=1000,!height:5"},
+		{name: "option field", resource: "test_block", filters: "type:stake"},
+		{name: "field of another resource", resource: "test_block", filters: "is_stake:true", err: ErrFilterFieldUnsupported},
+		{name: "unknown resource", resource: "test_unknown", filters: "height:1", err: ErrFilterFieldUnsupported},
+		{name: "unsupported operator", resource: "test_block", filters: "type>1", err: ErrFilterOperatorUnsupported},
+		{name: "unsupported negation", resource: "test_block", filters: "type!=stake", err: ErrFilterNegationUnsupported},
+		{name: "operators are not shared between resources", resource: "test_block", filters: "type:*", err: ErrFilterOperatorUnsupported},
+		{name: "declarations of a resource are merged", resource: "test_history", filters: "type:stake,!type:*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilters(tt.filters)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = CheckFilters(tt.resource, f)
+			if !errors.Is(err, tt.err) || (err == nil) != (tt.err == nil) {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+			if err != nil && !IsFilterError(err) {
+				t.Errorf("IsFilterError(%v) = false", err)
+			}
+		})
+	}
+}
+
+func TestFilterable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query  string
+		status int
+	}{
+		{query: "filters=height>=1000", status: 200},
+		{query: "", status: 200},
+		{query: "filters=is_stake:true", status: 400},
+		{query: "filters=height", status: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := gin.New()
+			r.Use(RR())
+			r.GET("/block", Filterable("test_block"), func(c *gin.Context) {
+				c.Status(200)
+			})
+
+			recorder := httptest.NewRecorder()
+			r.ServeHTTP(recorder, httptest.NewRequest("GET", "/block?"+tt.query, nil))
+
+			if recorder.Code != tt.status {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.status)
+			}
+		})
+	}
+}
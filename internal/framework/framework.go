@@ -18,12 +18,20 @@ func SetReleaseMode(debug bool) {
 }
 
 func NetworkSelect(c *gin.Context) {
+	c.Header("X-Network", networkName(c))
+}
+
+// networkName is the Network header, or the network query parameter for links which cannot set headers
+func networkName(c *gin.Context) string {
 	network := c.GetHeader("Network")
+	if network == "" {
+		network = c.Query("network")
+	}
 	if network == "" {
 		network = config.Get().DefaultNetwork
 	}
 
-	c.Header("X-Network", network)
+	return network
 }
 
 func Options(c *gin.Context) {
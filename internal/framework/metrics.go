@@ -0,0 +1,16 @@
+package framework
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/metrics"
+	"time"
+)
+
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		metrics.ObserveRequest(c.FullPath(), c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
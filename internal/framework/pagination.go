@@ -1,6 +1,9 @@
 package framework
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"strconv"
 )
@@ -11,31 +14,87 @@ const (
 	maxSize     int = 1000
 )
 
+var (
+	ErrInvalidPagination = errors.New("The page and size parameters must be integers")
+	ErrInvalidCursor     = errors.New("The cursor parameter is an invalid format")
+)
+
 type Pagination interface {
 	Page() int
 	Size() int
 	From() int
+	Cursor() *Cursor
+	SetCursors(next, prev string)
+	Next() string
+	Prev() string
+}
+
+// Cursor holds the sort values of the hit to search after. A reverse cursor
+// pages backwards from the hit by searching in the opposite sort direction.
+type Cursor struct {
+	Values  []interface{} `json:"v,omitempty"`
+	Reverse bool          `json:"r,omitempty"`
+}
+
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (*Cursor, error) {
+	cursor := new(Cursor)
+	if value == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func (c *Cursor) IsStart() bool {
+	return len(c.Values) == 0
 }
 
 type pagination struct {
-	page int
-	size int
+	page   int
+	size   int
+	cursor *Cursor
+	next   string
+	prev   string
 }
 
-func newPagination(page, size int) Pagination {
+func NewPagination(page, size int) Pagination {
 	return &pagination{
 		page: page,
 		size: size,
 	}
 }
 
+// NewCursorPagination requests size hits after the cursor
+func NewCursorPagination(size int, cursor *Cursor) Pagination {
+	return &pagination{
+		page:   defaultPage,
+		size:   size,
+		cursor: cursor,
+	}
+}
+
 func newPaginationFromContext(c *gin.Context) (Pagination, error) {
 	page := defaultPage
 	pageParam, exists := c.GetQuery("page")
 	if exists == true {
 		p, err := strconv.Atoi(pageParam)
 		if err != nil {
-			return newPagination(defaultPage, defaultSize), err
+			return NewPagination(defaultPage, defaultSize), ErrInvalidPagination
 		}
 		page = p
 	}
@@ -45,7 +104,7 @@ func newPaginationFromContext(c *gin.Context) (Pagination, error) {
 	if exists == true {
 		s, err := strconv.Atoi(sizeParam)
 		if err != nil {
-			return newPagination(defaultSize, defaultPage), err
+			return NewPagination(defaultPage, defaultSize), ErrInvalidPagination
 		}
 		if s > maxSize {
 			s = maxSize
@@ -53,7 +112,18 @@ func newPaginationFromContext(c *gin.Context) (Pagination, error) {
 		size = s
 	}
 
-	return newPagination(page, size), nil
+	// Cursor pagination is opt-in, an empty cursor requests the first page
+	cursorParam, exists := c.GetQuery("cursor")
+	if exists == true {
+		cursor, err := decodeCursor(cursorParam)
+		if err != nil {
+			return NewPagination(defaultPage, defaultSize), err
+		}
+
+		return &pagination{page: defaultPage, size: size, cursor: cursor}, nil
+	}
+
+	return NewPagination(page, size), nil
 }
 
 func (p *pagination) Page() int {
@@ -67,3 +137,20 @@ func (p *pagination) Size() int {
 func (p *pagination) From() int {
 	return (p.page * p.size) - p.size
 }
+
+func (p *pagination) Cursor() *Cursor {
+	return p.cursor
+}
+
+func (p *pagination) SetCursors(next, prev string) {
+	p.next = next
+	p.prev = prev
+}
+
+func (p *pagination) Next() string {
+	return p.next
+}
+
+func (p *pagination) Prev() string {
+	return p.prev
+}
@@ -0,0 +1,74 @@
+package framework
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNewPaginationFromContext(t *testing.T) {
+	cursor := EncodeCursor(Cursor{Values: []interface{}{float64(100), "abc"}, Reverse: true})
+
+	tests := []struct {
+		name   string
+		query  string
+		page   int
+		size   int
+		cursor *Cursor
+		err    error
+	}{
+		{name: "defaults", query: "", page: defaultPage, size: defaultSize},
+		{name: "page and size", query: "page=3&size=20", page: 3, size: 20},
+		{name: "size is capped", query: "size=5000", page: defaultPage, size: maxSize},
+		{name: "invalid page", query: "page=first", err: ErrInvalidPagination},
+		{name: "invalid size", query: "size=all", err: ErrInvalidPagination},
+		{name: "empty cursor starts at the first page", query: "cursor=&size=5", page: defaultPage, size: 5, cursor: &Cursor{}},
+		{name: "cursor ignores the page", query: "page=4&cursor=" + cursor, page: defaultPage, size: defaultSize, cursor: &Cursor{Values: []interface{}{float64(100), "abc"}, Reverse: true}},
+		{name: "cursor that is not base64", query: "cursor=not*base64", err: ErrInvalidCursor},
+		{name: "cursor that is not json", query: "cursor=bm90IGpzb24", err: ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/?"+tt.query, nil)
+
+			p, err := newPaginationFromContext(c)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if p.Page() != tt.page || p.Size() != tt.size {
+				t.Errorf("Page() = %d, Size() = %d, want %d, %d", p.Page(), p.Size(), tt.page, tt.size)
+			}
+			if !reflect.DeepEqual(p.Cursor(), tt.cursor) {
+				t.Errorf("Cursor() = %+v, want %+v", p.Cursor(), tt.cursor)
+			}
+		})
+	}
+}
+
+func TestRRRejectsInvalidParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, query := range []string{"cursor=not*base64", "page=first", "sort=height"} {
+		t.Run(query, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(recorder)
+			c.Request = httptest.NewRequest("GET", "/?"+query, nil)
+
+			RR()(c)
+
+			if !c.IsAborted() || recorder.Code != 400 {
+				t.Errorf("aborted = %v, status = %d, want a 400", c.IsAborted(), recorder.Code)
+			}
+			if _, exists := c.Get(REST); exists {
+				t.Error("the rest request is set")
+			}
+		})
+	}
+}
@@ -8,13 +8,15 @@ import (
 )
 
 type Paginator struct {
-	First            bool  `json:"first"`
-	Last             bool  `json:"last"`
-	Total            int64 `json:"total"`
-	PageSize         int   `json:"size"`
-	CurrentPage      int   `json:"current_page"`
-	Pages            int   `json:"total_pages"`
-	NumberOfElements int   `json:"number_of_elements"`
+	First            bool   `json:"first"`
+	Last             bool   `json:"last"`
+	Total            int64  `json:"total"`
+	PageSize         int    `json:"size"`
+	CurrentPage      int    `json:"current_page"`
+	Pages            int    `json:"total_pages"`
+	NumberOfElements int    `json:"number_of_elements"`
+	Next             string `json:"next,omitempty"`
+	Prev             string `json:"prev,omitempty"`
 }
 
 type Paginated struct {
@@ -28,6 +30,20 @@ func NewPaginator(numberOfElements int, total int64, pagination framework.Pagina
 		pages = 1
 	}
 
+	if cursor := pagination.Cursor(); cursor != nil {
+		return Paginator{
+			CurrentPage:      pagination.Page(),
+			Total:            total,
+			PageSize:         pagination.Size(),
+			Pages:            pages,
+			NumberOfElements: numberOfElements,
+			First:            pagination.Prev() == "",
+			Last:             pagination.Next() == "",
+			Next:             pagination.Next(),
+			Prev:             pagination.Prev(),
+		}
+	}
+
 	return Paginator{
 		CurrentPage:      pagination.Page(),
 		Total:            total,
@@ -0,0 +1,114 @@
+package framework
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/ratelimit"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/auth"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrRateLimitExceeded = errors.New("Rate limit exceeded")
+
+// ApiKey returns the API key from the X-API-Key header.
+// Keys are not read from the query so they do not end up in access logs, proxies and browser history.
+func ApiKey(c *gin.Context) string {
+	return c.GetHeader("X-API-Key")
+}
+
+// ClientIP returns the IP the request came from. X-Forwarded-For is only used when the request came through a trusted proxy,
+// and then the client is the last address in it which is not a trusted proxy.
+func ClientIP(c *gin.Context, trustedProxies []*net.IPNet) string {
+	host, _, err := net.SplitHostPort(strings.TrimSpace(c.Request.RemoteAddr))
+	if err != nil {
+		host = strings.TrimSpace(c.Request.RemoteAddr)
+	}
+	if !trusted(host, trustedProxies) {
+		return host
+	}
+
+	forwarded := strings.Split(c.GetHeader("X-Forwarded-For"), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(forwarded[i])
+		if net.ParseIP(ip) == nil {
+			break
+		}
+		host = ip
+		if !trusted(ip, trustedProxies) {
+			break
+		}
+	}
+
+	return host
+}
+
+func trusted(host string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RateLimit limits clients by API key, or by IP when no key is given, using the cost of the matched route.
+// A key is either in the rate limit config or the credential store, and keys in a tier that is not configured are not limited.
+func RateLimit(limiter *ratelimit.Limiter, rateLimitConfig config.RateLimitConfig, authService auth.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cost := 1
+		if routeCost, ok := rateLimitConfig.Costs[c.FullPath()]; ok {
+			cost = routeCost
+		}
+		if cost == 0 {
+			return
+		}
+
+		client, tierName := "ip:"+ClientIP(c, rateLimitConfig.TrustedProxies), rateLimitConfig.AnonymousTier
+		if key := ApiKey(c); key != "" {
+			keyTier, ok := rateLimitConfig.Keys[key]
+			if !ok {
+				credential, err := authService.Authenticate(key)
+				if err != nil {
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": err.Error(), "status": http.StatusUnauthorized})
+					return
+				}
+				keyTier = credential.Tier
+			}
+			client = "key:" + key
+			if keyTier != "" {
+				tierName = keyTier
+			}
+		}
+
+		tier, ok := rateLimitConfig.Tiers[tierName]
+		if !ok {
+			return
+		}
+
+		result := limiter.Allow(tierName+":"+client, tier.Rate, tier.Burst, cost)
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", seconds(result.Reset))
+
+		if !result.Allowed {
+			c.Header("Retry-After", seconds(result.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"message": ErrRateLimitExceeded.Error(), "status": http.StatusTooManyRequests})
+		}
+	}
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
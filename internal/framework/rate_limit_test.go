@@ -0,0 +1,50 @@
+package framework
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestClientIP(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+	trustedProxies := []*net.IPNet{proxies}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		trustProxies bool
+		want         string
+	}{
+		{name: "direct", remoteAddr: "203.0.113.1:1234", want: "203.0.113.1"},
+		{name: "forwarded header from an untrusted client", remoteAddr: "203.0.113.1:1234", forwardedFor: "198.51.100.1", trustProxies: true, want: "203.0.113.1"},
+		{name: "forwarded header without trusted proxies", remoteAddr: "10.0.0.1:1234", forwardedFor: "198.51.100.1", want: "10.0.0.1"},
+		{name: "forwarded by a trusted proxy", remoteAddr: "10.0.0.1:1234", forwardedFor: "198.51.100.1", trustProxies: true, want: "198.51.100.1"},
+		{name: "spoofed address before the client", remoteAddr: "10.0.0.1:1234", forwardedFor: "192.0.2.1, 198.51.100.1", trustProxies: true, want: "198.51.100.1"},
+		{name: "chain of trusted proxies", remoteAddr: "10.0.0.1:1234", forwardedFor: "198.51.100.1, 10.0.0.2", trustProxies: true, want: "198.51.100.1"},
+		{name: "invalid forwarded address", remoteAddr: "10.0.0.1:1234", forwardedFor: "unknown", trustProxies: true, want: "10.0.0.1"},
+		{name: "ipv6", remoteAddr: "[2001:db8::1]:1234", want: "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			c.Request.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				c.Request.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+
+			var proxies []*net.IPNet
+			if tt.trustProxies {
+				proxies = trustedProxies
+			}
+			if got := ClientIP(c, proxies); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
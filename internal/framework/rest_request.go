@@ -1,10 +1,11 @@
 package framework
 
 import (
-	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
+	"errors"
 	networkService "github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"net/http"
 )
 
 const REST string = "rest"
@@ -28,20 +29,27 @@ type restRequest struct {
 func RR() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := newRestRequestFromContext(c)
+		if isRequestError(err) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error(), "status": http.StatusBadRequest})
+			return
+		}
 		if err != nil {
 			logrus.WithError(err).Error("Failed to create rest request")
 		}
 	}
 }
 
+// isRequestError reports whether the query parameters of the request are invalid, rather than the server failing
+func isRequestError(err error) bool {
+	return IsFilterError(err) ||
+		errors.Is(err, ErrInvalidPagination) ||
+		errors.Is(err, ErrInvalidCursor) ||
+		errors.Is(err, ErrInvalidSortValue) ||
+		errors.Is(err, ErrInvalidSortDirection)
+}
+
 func newRestRequestFromContext(c *gin.Context) error {
-	network, err := networkService.GetNetwork(func(c *gin.Context) string {
-		n := c.GetHeader("Network")
-		if n == "" {
-			n = config.Get().DefaultNetwork
-		}
-		return n
-	}(c))
+	network, err := networkService.GetNetwork(networkName(c))
 
 	if err != nil {
 		return err
@@ -73,6 +81,16 @@ func newRestRequestFromContext(c *gin.Context) error {
 	return nil
 }
 
+// NewRestRequest creates a request for callers which are not bound to the REST query parameters
+func NewRestRequest(network networkService.Network, pagination Pagination, filters Filters, sort Sort) RestRequest {
+	return &restRequest{
+		network:    network,
+		pagination: pagination,
+		filters:    filters,
+		sort:       sort,
+	}
+}
+
 func (rr *restRequest) Network() networkService.Network {
 	return rr.network
 }
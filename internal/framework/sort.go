@@ -33,6 +33,11 @@ func newSortFromContext(c *gin.Context, n network.Network) (Sort, error) {
 		return NewSort(nil), nil
 	}
 
+	return ParseSort(sortQuery, n)
+}
+
+// ParseSort parses a comma separated list of field:direction sort options
+func ParseSort(sortQuery string, n network.Network) (Sort, error) {
 	options := make([]SortOption, 0)
 	for _, param := range strings.Split(sortQuery, ",") {
 		optionArray := strings.Split(param, ":")
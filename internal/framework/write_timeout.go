@@ -0,0 +1,34 @@
+package framework
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"time"
+)
+
+type connContextKey struct{}
+
+// ConnContext keeps each request's connection in its context so a route can change the server's write timeout
+func ConnContext(ctx context.Context, conn net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, conn)
+}
+
+// WriteTimeout replaces the server's write timeout for routes which stream their response,
+// a zero timeout removes the deadline. The server resets the deadline for the next request on the connection.
+func WriteTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if conn, ok := c.Request.Context().Value(connContextKey{}).(net.Conn); ok {
+			deadline := time.Time{}
+			if timeout > 0 {
+				deadline = time.Now().Add(timeout)
+			}
+			if err := conn.SetWriteDeadline(deadline); err != nil {
+				log.WithError(err).Warn("Failed to set the write deadline")
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,267 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaxDepth      = 10
+	MaxAliases    = 20
+	MaxComplexity = 500
+
+	// listEstimate is the assumed length of a list which is not paged, such as a block's transactions
+	listEstimate = 10
+	defaultSize  = 10
+)
+
+var (
+	ErrQueryTooDeep    = fmt.Errorf("The query is nested more than %d levels deep", MaxDepth)
+	ErrTooManyAliases  = fmt.Errorf("The query has more than %d aliases", MaxAliases)
+	ErrQueryTooComplex = fmt.Errorf("The query would resolve more than %d connections", MaxComplexity)
+)
+
+func IsLimitError(err error) bool {
+	return errors.Is(err, ErrQueryTooDeep) || errors.Is(err, ErrTooManyAliases) || errors.Is(err, ErrQueryTooComplex)
+}
+
+// CheckLimits rejects a query before it is executed when it is too deep, has too many aliases,
+// or would call the services too many times.
+// Each field with a selection resolves a connection once for each parent, so the complexity of a field
+// under a list is multiplied by the list's page size or its estimated length.
+// A query which cannot be parsed is left for execution to report.
+func CheckLimits(schema graphql.Schema, query string, operationName string, variables map[string]interface{}) error {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+
+	c := &limitChecker{schema: schema, variables: variables, fragments: make(map[string]*ast.FragmentDefinition)}
+	for _, definition := range document.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			c.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok || (operationName != "" && (operation.Name == nil || operation.Name.Value != operationName)) {
+			continue
+		}
+
+		c.aliases = 0
+		complexity, err := c.selections(operation.SelectionSet, schema.QueryType(), 1, 1, 0, make(map[string]bool))
+		if err != nil {
+			return err
+		}
+		if complexity > MaxComplexity {
+			return fmt.Errorf("%w, it would resolve %d", ErrQueryTooComplex, complexity)
+		}
+	}
+
+	return nil
+}
+
+type limitChecker struct {
+	schema    graphql.Schema
+	variables map[string]interface{}
+	fragments map[string]*ast.FragmentDefinition
+	aliases   int
+}
+
+// selections returns the number of connections resolved by the selection set of a parent resolved multiplier times,
+// where a page parent has the page size
+func (c *limitChecker) selections(set *ast.SelectionSet, parent graphql.Type, depth, multiplier, pageSize int, spread map[string]bool) (int, error) {
+	if set == nil {
+		return 0, nil
+	}
+	if depth > MaxDepth {
+		return 0, ErrQueryTooDeep
+	}
+
+	complexity := 0
+	for _, selection := range set.Selections {
+		var cost int
+		var err error
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			cost, err = c.field(selection, parent, depth, multiplier, pageSize, spread)
+		case *ast.InlineFragment:
+			cost, err = c.selections(selection.SelectionSet, c.typeCondition(selection.TypeCondition, parent), depth, multiplier, pageSize, spread)
+		case *ast.FragmentSpread:
+			fragment, ok := c.fragments[selection.Name.Value]
+			if !ok || spread[fragment.Name.Value] {
+				continue
+			}
+			spread[fragment.Name.Value] = true
+			cost, err = c.selections(fragment.SelectionSet, c.typeCondition(fragment.TypeCondition, parent), depth, multiplier, pageSize, spread)
+			delete(spread, fragment.Name.Value)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		complexity += cost
+		if complexity > MaxComplexity {
+			return complexity, nil
+		}
+	}
+
+	return complexity, nil
+}
+
+func (c *limitChecker) field(field *ast.Field, parent graphql.Type, depth, multiplier, pageSize int, spread map[string]bool) (int, error) {
+	// Introspection is answered from the schema without calling the services
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, nil
+	}
+
+	if field.Alias != nil {
+		c.aliases++
+		if c.aliases > MaxAliases {
+			return 0, ErrTooManyAliases
+		}
+	}
+
+	if field.SelectionSet == nil {
+		return 0, nil
+	}
+
+	elementType, isList := unwrapList(c.fieldType(parent, field.Name.Value))
+
+	childMultiplier, childPageSize := multiplier, 0
+	switch {
+	case isList && c.isPage(parent):
+		childMultiplier *= pageSize
+	case isList:
+		childMultiplier *= c.listLength(field)
+	case c.isPage(elementType):
+		childPageSize = c.listLength(field)
+	}
+	// Past the limit the exact complexity does not matter, and nested lists would overflow it
+	if childMultiplier > MaxComplexity {
+		childMultiplier = MaxComplexity + 1
+	}
+
+	children, err := c.selections(field.SelectionSet, elementType, depth+1, childMultiplier, childPageSize, spread)
+	if err != nil {
+		return 0, err
+	}
+
+	return multiplier + children, nil
+}
+
+// listLength is the page size of a paged field, or the estimated length of a list
+func (c *limitChecker) listLength(field *ast.Field) int {
+	if size, ok := c.intArgument(field, "size"); ok {
+		return clampSize(size)
+	}
+	if hasArgument(field, "size") || hasArgument(field, "page") {
+		return defaultSize
+	}
+
+	return listEstimate
+}
+
+// isPage is true for the page types, whose elements are resolved with the page rather than one by one
+func (c *limitChecker) isPage(t graphql.Type) bool {
+	object, ok := t.(*graphql.Object)
+	if !ok {
+		return false
+	}
+	_, hasElements := object.Fields()["elements"]
+	_, hasTotal := object.Fields()["total"]
+
+	return hasElements && hasTotal
+}
+
+func (c *limitChecker) fieldType(parent graphql.Type, name string) graphql.Type {
+	object, ok := parent.(*graphql.Object)
+	if !ok {
+		return nil
+	}
+	definition, ok := object.Fields()[name]
+	if !ok {
+		return nil
+	}
+
+	return definition.Type
+}
+
+func (c *limitChecker) typeCondition(condition *ast.Named, parent graphql.Type) graphql.Type {
+	if condition == nil || condition.Name == nil {
+		return parent
+	}
+	if t := c.schema.Type(condition.Name.Value); t != nil {
+		return t
+	}
+
+	return parent
+}
+
+func (c *limitChecker) intArgument(field *ast.Field, name string) (int, bool) {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != name {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			size, err := strconv.Atoi(value.Value)
+			return size, err == nil
+		case *ast.Variable:
+			switch variable := c.variables[value.Name.Value].(type) {
+			case float64:
+				return int(variable), true
+			case int:
+				return variable, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func hasArgument(field *ast.Field, name string) bool {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unwrapList returns the element type of a list, or the type itself when it is not a list
+func unwrapList(t graphql.Type) (graphql.Type, bool) {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	if list, ok := t.(*graphql.List); ok {
+		element := list.OfType
+		if nonNull, ok := element.(*graphql.NonNull); ok {
+			element = nonNull.OfType
+		}
+		return element, true
+	}
+
+	return t, false
+}
+
+// clampSize matches the page size the resolvers use
+func clampSize(size int) int {
+	if size < 1 {
+		return defaultSize
+	}
+	if size > maxPageSize {
+		return maxPageSize
+	}
+
+	return size
+}
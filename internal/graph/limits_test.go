@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckLimits(t *testing.T) {
+	schema, err := NewSchema(nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		err       error
+	}{
+		{
+			name:  "page of blocks with their transactions",
+			query: `{ blocks(size: 10) { total elements { hash transactions { hash } } } }`,
+		},
+		{
+			name:  "introspection",
+			query: `{ __schema { types { name fields { name type { name ofType { name ofType { name ofType { name ofType { name } } } } } } } } }`,
+		},
+		{
+			name:  "too deep",
+			query: `{ block(hash: "1") { transactions { block { transactions { block { transactions { block { transactions { block { transactions { hash } } } } } } } } } } }`,
+			err:   ErrQueryTooDeep,
+		},
+		{
+			name:  "too many aliases",
+			query: "{ " + strings.Repeat("a: bestBlock { hash } ", MaxAliases+1) + "}",
+			err:   ErrTooManyAliases,
+		},
+		{
+			name:  "nested pages",
+			query: `{ addresses(size: 1000) { elements { history(size: 10) { elements { hash } } } } }`,
+			err:   ErrQueryTooComplex,
+		},
+		{
+			name:      "page size from a variable",
+			query:     `query ($size: Int) { blocks(size: $size) { elements { transactions { hash } } } }`,
+			variables: map[string]interface{}{"size": float64(1000)},
+			err:       ErrQueryTooComplex,
+		},
+		{
+			name:  "fragments are counted",
+			query: `{ addresses(size: 1000) { elements { ...history } } } fragment history on Address { history(size: 10) { elements { hash } } }`,
+			err:   ErrQueryTooComplex,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckLimits(schema, tt.query, "", tt.variables)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("CheckLimits() = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
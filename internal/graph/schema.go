@@ -0,0 +1,445 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
+	addressEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao"
+	daoEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/softfork"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+)
+
+const maxPageSize = 1000
+
+type contextKey string
+
+const networkKey contextKey = "network"
+
+var ErrNetworkMissing = errors.New("Network is missing from the GraphQL context")
+
+// WithNetwork sets the network the query is resolved against
+func WithNetwork(ctx context.Context, n network.Network) context.Context {
+	return context.WithValue(ctx, networkKey, n)
+}
+
+func networkFrom(p graphql.ResolveParams) (network.Network, error) {
+	n, ok := p.Context.Value(networkKey).(network.Network)
+	if !ok {
+		return network.Network{}, ErrNetworkMissing
+	}
+
+	return n, nil
+}
+
+type schema struct {
+	types           *types
+	blockService    block.Service
+	addressService  address.Service
+	daoService      dao.Service
+	softForkService softfork.Service
+}
+
+// Page is a page of elements with the total number of matching elements
+type Page struct {
+	Elements interface{} `json:"elements"`
+	Total    int64       `json:"total"`
+}
+
+func NewSchema(blockService block.Service, addressService address.Service, daoService dao.Service, softForkService softfork.Service) (graphql.Schema, error) {
+	s := &schema{
+		types:           newTypes(),
+		blockService:    blockService,
+		addressService:  addressService,
+		daoService:      daoService,
+		softForkService: softForkService,
+	}
+	s.connect()
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: s.query()})
+}
+
+var pageArgs = graphql.FieldConfigArgument{
+	"page":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+	"size":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+	"sort":    &graphql.ArgumentConfig{Type: graphql.String, Description: "Comma separated sort options, e.g. height:desc"},
+	"filters": &graphql.ArgumentConfig{Type: graphql.String, Description: "Comma separated filter expressions, e.g. height>=1000"},
+}
+
+var hashArgs = graphql.FieldConfigArgument{
+	"hash": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+}
+
+func daoArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	result := graphql.FieldConfigArgument{
+		"page":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+		"size":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+		"state": &graphql.ArgumentConfig{Type: graphql.Int},
+	}
+	for name, arg := range args {
+		result[name] = arg
+	}
+
+	return result
+}
+
+func (s *schema) page(name string, element interface{}) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"elements": &graphql.Field{Type: s.types.list(element), Resolve: fieldResolver([]int{0})},
+			"total":    &graphql.Field{Type: Int64, Resolve: fieldResolver([]int{1})},
+		},
+	})
+}
+
+func (s *schema) query() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"bestBlock": &graphql.Field{
+				Type: s.types.object(explorer.Block{}),
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					return s.blockService.GetBestBlock(n)
+				}),
+			},
+			"blockCycle": &graphql.Field{
+				Type:        s.types.object(daoEntity.LegacyBlockCycle{}),
+				Description: "The block cycle of the best block",
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					bestBlock, err := s.blockService.GetBestBlock(n)
+					if err != nil {
+						return nil, err
+					}
+					return s.daoService.GetBlockCycleByBlock(n, bestBlock)
+				}),
+			},
+			"block": &graphql.Field{
+				Type:        s.types.object(explorer.Block{}),
+				Description: "A block by hash or height",
+				Args:        hashArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					return optional(s.blockService.GetBlock(n, p.Args["hash"].(string)))
+				}),
+			},
+			"blocks": &graphql.Field{
+				Type: s.page("BlockPage", explorer.Block{}),
+				Args: pageArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					request, err := restRequest(n, p, repository.BlockFilters)
+					if err != nil {
+						return nil, err
+					}
+					blocks, total, err := s.blockService.GetBlocks(n, request)
+					return &Page{blocks, total}, err
+				}),
+			},
+			"transaction": &graphql.Field{
+				Type: s.types.object(explorer.BlockTransaction{}),
+				Args: hashArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					return optional(s.blockService.GetTransactionByHash(n, p.Args["hash"].(string)))
+				}),
+			},
+			"transactions": &graphql.Field{
+				Type: s.page("TransactionPage", explorer.BlockTransaction{}),
+				Args: pageArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					request, err := restRequest(n, p, repository.TransactionFilters)
+					if err != nil {
+						return nil, err
+					}
+					txs, total, err := s.blockService.GetTransactions(n, request)
+					return &Page{txs, total}, err
+				}),
+			},
+			"address": &graphql.Field{
+				Type: s.types.object(explorer.Address{}),
+				Args: hashArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					return optional(s.addressService.GetAddress(n, p.Args["hash"].(string)))
+				}),
+			},
+			"addresses": &graphql.Field{
+				Type:        s.page("AddressPage", explorer.Address{}),
+				Description: "Addresses ordered by balance",
+				Args:        pageArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					request, err := restRequest(n, p, repository.AddressFilters)
+					if err != nil {
+						return nil, err
+					}
+					addresses, total, err := s.addressService.GetAddresses(n, request.Pagination(), request.Filters(), request.Sort())
+					return &Page{addresses, total}, err
+				}),
+			},
+			"proposal": &graphql.Field{
+				Type: s.types.object(explorer.Proposal{}),
+				Args: hashArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					return optional(s.daoService.GetProposal(n, p.Args["hash"].(string)))
+				}),
+			},
+			"proposals": &graphql.Field{
+				Type: s.page("ProposalPage", explorer.Proposal{}),
+				Args: daoArgs(nil),
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					parameters := dao.ProposalParameters{State: uintArg(p, "state")}
+					proposals, total, err := s.daoService.GetProposals(n, parameters, pagination(p))
+					return &Page{proposals, total}, err
+				}),
+			},
+			"paymentRequest": &graphql.Field{
+				Type: s.types.object(explorer.PaymentRequest{}),
+				Args: hashArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					return optional(s.daoService.GetPaymentRequest(n, p.Args["hash"].(string)))
+				}),
+			},
+			"paymentRequests": &graphql.Field{
+				Type: s.page("PaymentRequestPage", explorer.PaymentRequest{}),
+				Args: daoArgs(graphql.FieldConfigArgument{
+					"proposal": &graphql.ArgumentConfig{Type: graphql.String},
+				}),
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					parameters := dao.PaymentRequestParameters{State: uintArg(p, "state")}
+					if proposal, ok := p.Args["proposal"].(string); ok {
+						parameters.Proposal = proposal
+					}
+					paymentRequests, total, err := s.daoService.GetPaymentRequests(n, parameters, pagination(p))
+					return &Page{paymentRequests, total}, err
+				}),
+			},
+			"consultation": &graphql.Field{
+				Type: s.types.object(explorer.Consultation{}),
+				Args: hashArgs,
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					return optional(s.daoService.GetConsultation(n, p.Args["hash"].(string)))
+				}),
+			},
+			"consultations": &graphql.Field{
+				Type: s.page("ConsultationPage", explorer.Consultation{}),
+				Args: daoArgs(graphql.FieldConfigArgument{
+					"consensus": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"min":       &graphql.ArgumentConfig{Type: graphql.Int},
+				}),
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					parameters := dao.ConsultationParameters{State: uintArg(p, "state"), Min: uintArg(p, "min")}
+					if consensus, ok := p.Args["consensus"].(bool); ok {
+						parameters.Consensus = &consensus
+					}
+					consultations, total, err := s.daoService.GetConsultations(n, parameters, pagination(p))
+					return &Page{consultations, total}, err
+				}),
+			},
+			"softForks": &graphql.Field{
+				Type: s.types.list(explorer.SoftFork{}),
+				Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+					return s.softForkService.GetSoftForks(n)
+				}),
+			},
+		},
+	})
+}
+
+// connect adds the fields which link the types into a graph
+func (s *schema) connect() {
+	s.types.extend(explorer.Block{}, graphql.Fields{
+		"transactions": &graphql.Field{
+			Type: s.types.list(explorer.BlockTransaction{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return s.blockService.GetTransactionsByBlockHash(n, p.Source.(*explorer.Block).Hash)
+			}),
+		},
+		"cycle": &graphql.Field{
+			Type: s.types.object(daoEntity.LegacyBlockCycle{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return s.daoService.GetBlockCycleByBlock(n, p.Source.(*explorer.Block))
+			}),
+		},
+	})
+
+	s.types.extend(explorer.BlockTransaction{}, graphql.Fields{
+		"block": &graphql.Field{
+			Type: s.types.object(explorer.Block{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return optional(s.blockService.GetBlock(n, p.Source.(*explorer.BlockTransaction).BlockHash))
+			}),
+		},
+	})
+
+	s.types.extend(explorer.Address{}, graphql.Fields{
+		"summary": &graphql.Field{
+			Type: s.types.object(addressEntity.AddressSummary{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return s.addressService.GetAddressSummary(n, p.Source.(*explorer.Address).Hash)
+			}),
+		},
+		"history": &graphql.Field{
+			Type: s.page("AddressHistoryPage", explorer.AddressHistory{}),
+			Args: pageArgs,
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				request, err := restRequest(n, p, repository.AddressHistoryFilters)
+				if err != nil {
+					return nil, err
+				}
+				history, total, err := s.addressService.GetHistory(n, p.Source.(*explorer.Address).Hash, request)
+				return &Page{history, total}, err
+			}),
+		},
+	})
+
+	s.types.extend(explorer.AddressHistory{}, graphql.Fields{
+		"transaction": &graphql.Field{
+			Type: s.types.object(explorer.BlockTransaction{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return optional(s.blockService.GetTransactionByHash(n, p.Source.(*explorer.AddressHistory).TxId))
+			}),
+		},
+	})
+
+	s.types.extend(explorer.Proposal{}, graphql.Fields{
+		"votes": &graphql.Field{
+			Type: s.types.list(daoEntity.CfundVote{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				votes, _, err := s.daoService.GetProposalVotes(n, p.Source.(*explorer.Proposal).Hash)
+				return votes, err
+			}),
+		},
+		"trend": &graphql.Field{
+			Type: s.types.list(daoEntity.CfundTrend{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return s.daoService.GetProposalTrend(n, p.Source.(*explorer.Proposal).Hash)
+			}),
+		},
+		"paymentRequests": &graphql.Field{
+			Type: s.types.list(explorer.PaymentRequest{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return s.daoService.GetPaymentRequestsForProposal(n, p.Source.(*explorer.Proposal))
+			}),
+		},
+	})
+
+	s.types.extend(explorer.PaymentRequest{}, graphql.Fields{
+		"proposal": &graphql.Field{
+			Type: s.types.object(explorer.Proposal{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return optional(s.daoService.GetProposal(n, p.Source.(*explorer.PaymentRequest).ProposalHash))
+			}),
+		},
+		"votes": &graphql.Field{
+			Type: s.types.list(daoEntity.CfundVote{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				votes, _, err := s.daoService.GetPaymentRequestVotes(n, p.Source.(*explorer.PaymentRequest).Hash)
+				return votes, err
+			}),
+		},
+		"trend": &graphql.Field{
+			Type: s.types.list(daoEntity.CfundTrend{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				return s.daoService.GetPaymentRequestTrend(n, p.Source.(*explorer.PaymentRequest).Hash)
+			}),
+		},
+	})
+
+	s.types.extend(explorer.Answer{}, graphql.Fields{
+		"votes": &graphql.Field{
+			Type: s.types.list(daoEntity.CfundVote{}),
+			Resolve: s.resolve(func(n network.Network, p graphql.ResolveParams) (interface{}, error) {
+				answer := p.Source.(explorer.Answer)
+				votes, _, err := s.daoService.GetAnswerVotes(n, answer.Parent, answer.Hash)
+				return votes, err
+			}),
+		},
+	})
+}
+
+func (s *schema) resolve(resolver func(n network.Network, p graphql.ResolveParams) (interface{}, error)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		n, err := networkFrom(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return resolver(n, p)
+	}
+}
+
+// optional resolves an element which is not found to null rather than an error
+func optional(value interface{}, err error) (interface{}, error) {
+	if err == nil {
+		return value, nil
+	}
+
+	for _, notFound := range []error{
+		repository.ErrBlockNotFound,
+		repository.ErrAddressNotFound,
+		repository.ErrProposalNotFound,
+		repository.ErrPaymentRequestNotFound,
+		repository.ErrConsultationNotFound,
+	} {
+		if errors.Is(err, notFound) {
+			return nil, nil
+		}
+	}
+
+	return nil, err
+}
+
+func pagination(p graphql.ResolveParams) framework.Pagination {
+	page, _ := p.Args["page"].(int)
+	if page < 1 {
+		page = 1
+	}
+
+	size, _ := p.Args["size"].(int)
+	if size < 1 {
+		size = 10
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	return framework.NewPagination(page, size)
+}
+
+func restRequest(n network.Network, p graphql.ResolveParams, filterResource string) (framework.RestRequest, error) {
+	sort := framework.NewSort(nil)
+	if value, ok := p.Args["sort"].(string); ok && value != "" {
+		parsed, err := framework.ParseSort(value, n)
+		if err != nil {
+			return nil, err
+		}
+		sort = parsed
+	}
+
+	filters := framework.NewFilters(nil)
+	if value, ok := p.Args["filters"].(string); ok && value != "" {
+		parsed, err := framework.ParseFilters(value)
+		if err != nil {
+			return nil, err
+		}
+		if err := framework.CheckFilters(filterResource, parsed); err != nil {
+			return nil, err
+		}
+		filters = parsed
+	}
+
+	return framework.NewRestRequest(n, pagination(p), filters, sort), nil
+}
+
+func uintArg(p graphql.ResolveParams, name string) *uint {
+	value, ok := p.Args[name].(int)
+	if !ok || value < 0 {
+		return nil
+	}
+
+	result := uint(value)
+	return &result
+}
@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"github.com/graphql-go/graphql"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	timeType              = reflect.TypeOf(time.Time{})
+	invalidNameCharacters = regexp.MustCompile(`[^_a-zA-Z0-9]`)
+)
+
+// types builds GraphQL object types from the JSON encoding of the explorer and entity structs,
+// so the graph exposes the same fields as the REST API. Connections to other types are added with extend.
+type types struct {
+	objects    map[reflect.Type]*graphql.Object
+	names      map[string]reflect.Type
+	extensions map[reflect.Type]graphql.Fields
+}
+
+func newTypes() *types {
+	return &types{
+		objects:    make(map[reflect.Type]*graphql.Object),
+		names:      make(map[string]reflect.Type),
+		extensions: make(map[reflect.Type]graphql.Fields),
+	}
+}
+
+// extend adds fields to the object type of v, it must be called before the schema is created
+func (t *types) extend(v interface{}, fields graphql.Fields) {
+	structType := indirect(reflect.TypeOf(v))
+	if t.extensions[structType] == nil {
+		t.extensions[structType] = graphql.Fields{}
+	}
+	for name, field := range fields {
+		t.extensions[structType][name] = field
+	}
+}
+
+func (t *types) object(v interface{}) *graphql.Object {
+	return t.output(reflect.TypeOf(v)).(*graphql.Object)
+}
+
+func (t *types) list(v interface{}) *graphql.List {
+	return graphql.NewList(t.object(v))
+}
+
+func (t *types) output(goType reflect.Type) graphql.Output {
+	goType = indirect(goType)
+
+	if goType == timeType {
+		return DateTime
+	}
+
+	switch goType.Kind() {
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return graphql.Int
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return Int64
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float
+	case reflect.String:
+		return graphql.String
+	case reflect.Slice, reflect.Array:
+		if goType.Elem().Kind() == reflect.Uint8 {
+			return graphql.String
+		}
+		return graphql.NewList(t.output(goType.Elem()))
+	case reflect.Struct:
+		if goType.Name() != "" {
+			return t.structObject(goType)
+		}
+	}
+
+	return JSON
+}
+
+func (t *types) structObject(goType reflect.Type) *graphql.Object {
+	if object, ok := t.objects[goType]; ok {
+		return object
+	}
+
+	name := goType.Name()
+	if existing, taken := t.names[name]; taken && existing != goType {
+		pkg := path.Base(goType.PkgPath())
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	t.names[name] = goType
+
+	// The fields are a thunk so recursive types and extensions resolve once every type is known
+	object := graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			fields := t.structFields(goType, nil)
+			for fieldName, field := range t.extensions[goType] {
+				fields[fieldName] = field
+			}
+			return fields
+		}),
+	})
+	t.objects[goType] = object
+
+	return object
+}
+
+func (t *types) structFields(goType reflect.Type, index []int) graphql.Fields {
+	fields := graphql.Fields{}
+
+	for i := 0; i < goType.NumField(); i++ {
+		field := goType.Field(i)
+		fieldIndex := append(append([]int{}, index...), i)
+
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag == "" && indirect(field.Type).Kind() == reflect.Struct {
+			for name, embedded := range t.structFields(indirect(field.Type), fieldIndex) {
+				fields[name] = embedded
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = field.Name
+		}
+		name = invalidNameCharacters.ReplaceAllString(name, "_")
+
+		fields[name] = &graphql.Field{
+			Type:    t.output(field.Type),
+			Resolve: fieldResolver(fieldIndex),
+		}
+	}
+
+	return fields
+}
+
+func fieldResolver(index []int) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		value := reflect.ValueOf(p.Source)
+		for _, i := range index {
+			for value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					return nil, nil
+				}
+				value = value.Elem()
+			}
+			if value.Kind() != reflect.Struct {
+				return nil, nil
+			}
+			value = value.Field(i)
+		}
+
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			return nil, nil
+		}
+
+		return value.Interface(), nil
+	}
+}
+
+func indirect(goType reflect.Type) reflect.Type {
+	for goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+
+	return goType
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/cache"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type cacheCollector struct {
+	cache           *cache.Cache
+	hits            *prometheus.Desc
+	misses          *prometheus.Desc
+	refreshes       *prometheus.Desc
+	refreshFailures *prometheus.Desc
+	items           *prometheus.Desc
+}
+
+// NewCacheCollector reads the cache stats when the metrics are scraped
+func NewCacheCollector(c *cache.Cache) prometheus.Collector {
+	return &cacheCollector{
+		cache:           c,
+		hits:            prometheus.NewDesc(namespace+"_cache_hits_total", "Cache lookups which found an item.", nil, nil),
+		misses:          prometheus.NewDesc(namespace+"_cache_misses_total", "Cache lookups which did not find an item.", nil, nil),
+		refreshes:       prometheus.NewDesc(namespace+"_cache_refreshes_total", "Cache items refreshed on a new block.", nil, nil),
+		refreshFailures: prometheus.NewDesc(namespace+"_cache_refresh_failures_total", "Cache items which failed to refresh.", nil, nil),
+		items:           prometheus.NewDesc(namespace+"_cache_items", "Items in the cache.", nil, nil),
+	}
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.refreshes
+	ch <- c.refreshFailures
+	ch <- c.items
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.cache.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.refreshes, prometheus.CounterValue, float64(stats.Refreshes))
+	ch <- prometheus.MustNewConstMetric(c.refreshFailures, prometheus.CounterValue, float64(stats.RefreshFailures))
+	ch <- prometheus.MustNewConstMetric(c.items, prometheus.GaugeValue, float64(c.cache.ItemCount()))
+}
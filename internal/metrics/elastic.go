@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	elasticRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "elasticsearch",
+		Name:      "requests_total",
+		Help:      "Elasticsearch requests by index and operation.",
+	}, []string{"index", "operation"})
+
+	elasticDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "elasticsearch",
+		Name:      "request_duration_seconds",
+		Help:      "Elasticsearch request latency by index and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"index", "operation"})
+
+	elasticErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "elasticsearch",
+		Name:      "errors_total",
+		Help:      "Elasticsearch requests which failed or returned an error status, by index and operation.",
+	}, []string{"index", "operation"})
+)
+
+type elasticTransport struct {
+	next http.RoundTripper
+}
+
+// NewElasticTransport wraps the transport of the Elasticsearch client so every repository query is measured
+func NewElasticTransport(next http.RoundTripper) http.RoundTripper {
+	return &elasticTransport{next: next}
+}
+
+func (t *elasticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	index, operation := elasticLabels(req)
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+
+	elasticRequests.WithLabelValues(index, operation).Inc()
+	elasticDuration.WithLabelValues(index, operation).Observe(time.Since(start).Seconds())
+
+	// A 404 is how Elasticsearch reports a missing document, it is not a failure
+	if err != nil || (res.StatusCode >= 400 && res.StatusCode != http.StatusNotFound) {
+		elasticErrors.WithLabelValues(index, operation).Inc()
+	}
+
+	return res, err
+}
+
+// elasticLabels takes the index and operation from the path, e.g. /mainnet.v2.block/_search
+func elasticLabels(req *http.Request) (index string, operation string) {
+	for _, segment := range strings.Split(strings.Trim(req.URL.Path, "/"), "/") {
+		if strings.HasPrefix(segment, "_") {
+			return index, segment
+		}
+		if index == "" {
+			index = segment
+		}
+	}
+
+	return index, strings.ToLower(req.Method)
+}
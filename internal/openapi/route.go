@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrRoutesUndocumented = errors.New("OpenAPI: Routes are missing from the specification")
+
+// Route describes a gin route. Path parameters are taken from the path so only the query
+// and header parameters need to be given.
+type Route struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Tag         string
+	Parameters  []*Parameter
+	RequestBody *RequestBody
+	Headers     map[string]*Header
+	Status      int
+	ContentType string
+	Response    interface{}
+	Security    string
+}
+
+var (
+	pathParamPattern = regexp.MustCompile(`[:*]([^/]+)`)
+	handlerPattern   = regexp.MustCompile(`\(\*(\w+)Resource\)\.(\w+)-fm$`)
+)
+
+// AddRoutes adds an operation for each registered route from its specification.
+// The routes which are not specified are left out and returned as an error.
+func (d *Document) AddRoutes(routes []Route, registered gin.RoutesInfo) error {
+	specs := make(map[string]Route)
+	for _, route := range routes {
+		specs[route.Method+" "+route.Path] = route
+	}
+
+	undocumented := make([]string, 0)
+	for _, info := range registered {
+		route, ok := specs[info.Method+" "+info.Path]
+		if !ok {
+			undocumented = append(undocumented, info.Method+" "+info.Path)
+			continue
+		}
+
+		specPath := pathParamPattern.ReplaceAllString(info.Path, "{$1}")
+		if d.Paths[specPath] == nil {
+			d.Paths[specPath] = &PathItem{}
+		}
+		(*d.Paths[specPath])[strings.ToLower(info.Method)] = d.operation(route, info.Handler)
+	}
+
+	if len(undocumented) != 0 {
+		sort.Strings(undocumented)
+		return fmt.Errorf("%w: %s", ErrRoutesUndocumented, strings.Join(undocumented, ", "))
+	}
+
+	return nil
+}
+
+func (d *Document) operation(route Route, handler string) *Operation {
+	operation := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		RequestBody: route.RequestBody,
+		Parameters:  make([]*Parameter, 0),
+		Responses:   make(map[string]*Response),
+	}
+	if operation.OperationID == "" {
+		operation.OperationID = operationID(route, handler)
+	}
+	if route.Tag != "" {
+		operation.Tags = []string{route.Tag}
+	}
+	if route.Security != "" {
+		operation.Security = []map[string][]string{{route.Security: {}}}
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	operation.Parameters = append(operation.Parameters, route.Parameters...)
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	contentType := route.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	response := &Response{Description: http.StatusText(status), Headers: route.Headers}
+	if route.Response != nil {
+		response.Content = map[string]*MediaType{
+			contentType: {Schema: d.Schema(route.Response)},
+		}
+	}
+	operation.Responses[strconv.Itoa(status)] = response
+
+	if _, ok := d.Components.Responses["Error"]; ok {
+		operation.Responses["default"] = ResponseRef("Error")
+	}
+
+	return operation
+}
+
+// operationID is taken from the resource handler, e.g. (*AddressResource).GetHistory is address.getHistory
+func operationID(route Route, handler string) string {
+	if match := handlerPattern.FindStringSubmatch(handler); match != nil {
+		return strings.ToLower(match[1][:1]) + match[1][1:] + "." + strings.ToLower(match[2][:1]) + match[2][1:]
+	}
+
+	id := strings.ToLower(route.Method)
+	for _, part := range strings.FieldsFunc(route.Path, func(r rune) bool { return r == '/' || r == ':' || r == '-' || r == '.' }) {
+		id += strings.ToUpper(part[:1]) + part[1:]
+	}
+
+	return id
+}
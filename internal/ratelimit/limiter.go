@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// How often buckets that have refilled are removed, a full bucket is the same as a new one
+const sweepInterval = time.Minute
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type bucket struct {
+	tokens  float64
+	rate    float64
+	burst   float64
+	updated time.Time
+}
+
+// Limiter is a set of token buckets keyed by client
+type Limiter struct {
+	buckets map[string]*bucket
+	swept   time.Time
+	mu      sync.Mutex
+}
+
+func NewLimiter() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		swept:   time.Now(),
+	}
+}
+
+// Allow takes cost tokens from the key's bucket, which refills at rate tokens per second up to burst
+func (l *Limiter) Allow(key string, rate float64, burst int, cost int) Result {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.swept) > sweepInterval {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok || b.rate != rate || b.burst != float64(burst) {
+		b = &bucket{tokens: float64(burst), rate: rate, burst: float64(burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.refill(now)
+
+	// A request costing more than the burst could never be allowed
+	required := math.Min(float64(cost), b.burst)
+
+	result := Result{Limit: burst}
+	if b.tokens >= required {
+		b.tokens -= required
+		result.Allowed = true
+	} else {
+		result.RetryAfter = b.wait(required - b.tokens)
+	}
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = b.wait(b.burst - b.tokens)
+
+	return result
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= b.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.swept = now
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.updated).Seconds()*b.rate)
+	b.updated = now
+}
+
+func (b *bucket) wait(tokens float64) time.Duration {
+	if tokens <= 0 || b.rate <= 0 {
+		return 0
+	}
+
+	return time.Duration(tokens / b.rate * float64(time.Second))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
 	log "github.com/sirupsen/logrus"
 	"reflect"
+	"time"
 )
 
 type cachingAddressHistoryRepository struct {
@@ -29,10 +30,22 @@ func (r *cachingAddressHistoryRepository) GetFirstByHash(n network.Network, hash
 	return r.repository.GetFirstByHash(n, hash)
 }
 
+func (r *cachingAddressHistoryRepository) GetLatestByHashAtHeight(n network.Network, hash string, height uint64) (*explorer.AddressHistory, error) {
+	return r.repository.GetLatestByHashAtHeight(n, hash, height)
+}
+
+func (r *cachingAddressHistoryRepository) GetLatestByHashAtTime(n network.Network, hash string, t time.Time) (*explorer.AddressHistory, error) {
+	return r.repository.GetLatestByHashAtTime(n, hash, t)
+}
+
 func (r *cachingAddressHistoryRepository) GetCountByHash(n network.Network, hash string) (int64, error) {
 	return r.repository.GetCountByHash(n, hash)
 }
 
+func (r *cachingAddressHistoryRepository) GetByHashAndTxid(n network.Network, hash string, txid string) (*explorer.AddressHistory, error) {
+	return r.repository.GetByHashAndTxid(n, hash, txid)
+}
+
 func (r *cachingAddressHistoryRepository) GetStakingSummary(n network.Network, hash string) (count, stakable, spendable, votingWeight int64, err error) {
 	return r.repository.GetStakingSummary(n, hash)
 }
@@ -45,6 +58,10 @@ func (r *cachingAddressHistoryRepository) GetHistoryByHash(n network.Network, ha
 	return r.repository.GetHistoryByHash(n, hash, p, s, f)
 }
 
+func (r *cachingAddressHistoryRepository) ExportHistoryByHash(n network.Network, hash string, s framework.Sort, f framework.Filters, callback func(history *explorer.AddressHistory) error) error {
+	return r.repository.ExportHistoryByHash(n, hash, s, f, callback)
+}
+
 func (r *cachingAddressHistoryRepository) GetAddressGroups(n network.Network, period *group.Period, count int) ([]entity.AddressGroup, error) {
 	addressGroup := make([]entity.AddressGroup, count)
 
@@ -84,6 +101,14 @@ func (r *cachingAddressHistoryRepository) StakingRewardsForAddresses(n network.N
 	return r.repository.StakingRewardsForAddresses(n, addresses)
 }
 
+func (r *cachingAddressHistoryRepository) GetStakers(n network.Network, from, to uint64, addresses []string) ([]*entity.Staker, error) {
+	return r.repository.GetStakers(n, from, to, addresses)
+}
+
+func (r *cachingAddressHistoryRepository) GetHistoryForAddresses(n network.Network, from, to uint64, addresses []string, callback func(history *explorer.AddressHistory) error) error {
+	return r.repository.GetHistoryForAddresses(n, from, to, addresses, callback)
+}
+
 func InterfaceSlice(slice interface{}) []interface{} {
 	s := reflect.ValueOf(slice)
 	if s.Kind() != reflect.Slice {
@@ -20,25 +20,54 @@ import (
 type AddressHistoryRepository interface {
 	GetLatestByHash(n network.Network, hash string) (*explorer.AddressHistory, error)
 	GetFirstByHash(n network.Network, hash string) (*explorer.AddressHistory, error)
+	GetLatestByHashAtHeight(n network.Network, hash string, height uint64) (*explorer.AddressHistory, error)
+	GetLatestByHashAtTime(n network.Network, hash string, t time.Time) (*explorer.AddressHistory, error)
 	GetCountByHash(n network.Network, hash string) (int64, error)
+	GetByHashAndTxid(n network.Network, hash string, txid string) (*explorer.AddressHistory, error)
 	GetStakingSummary(n network.Network, hash string) (count, stakable, spendable, votingWeight int64, err error)
 	GetSpendSummary(n network.Network, hash string) (spendableReceive, spendableSent, stakableReceive, stakableSent, votingWeightReceive, votingWeightSent int64, err error)
 	GetHistoryByHash(n network.Network, hash string, p framework.Pagination, s framework.Sort, f framework.Filters) ([]*explorer.AddressHistory, int64, error)
+	ExportHistoryByHash(n network.Network, hash string, s framework.Sort, f framework.Filters, callback func(history *explorer.AddressHistory) error) error
 	GetAddressGroups(n network.Network, period *group.Period, count int) ([]entity.AddressGroup, error)
 	GetAddressGroupsTotal(n network.Network, period *group.Period, count int) ([]entity.AddressGroupTotal, error)
 	GetStakingChart(n network.Network, period, hash string) (groups []*entity.StakingGroup, err error)
 	GetStakingRange(n network.Network, from, to uint64, address []string) (*entity.StakingBlocks, error)
 	StakingRewardsForAddresses(n network.Network, addresses []string) ([]*entity.StakingReward, error)
+	GetStakers(n network.Network, from, to uint64, addresses []string) ([]*entity.Staker, error)
+	GetHistoryForAddresses(n network.Network, from, to uint64, addresses []string, callback func(history *explorer.AddressHistory) error) error
 }
 
 var (
 	ErrAddressHistoryNotFound = errors.New("Address history not found")
 )
 
+const exportBatchSize = 1000
+
 type addressHistoryRepository struct {
 	elastic *elastic_cache.Index
 }
 
+var addressHistoryFilterFields = registerFilterFields(AddressHistoryFilters, filterFields{
+	"height":                valueField(""),
+	"txindex":               valueField(""),
+	"time":                  valueField(""),
+	"is_stake":              valueField(""),
+	"is_cfund_payout":       valueField(""),
+	"is_stake_payout":       valueField(""),
+	"is_multisig":           valueField(""),
+	"changes.spendable":     valueField("changes"),
+	"changes.stakable":      valueField("changes"),
+	"changes.voting_weight": valueField("changes"),
+	"balance.spendable":     valueField("balance"),
+	"balance.stakable":      valueField("balance"),
+	"balance.voting_weight": valueField("balance"),
+})
+
+// addressHistoryOptionFields are the filter options historyQuery reads itself
+var addressHistoryOptionFields = registerFilterFields(AddressHistoryFilters, filterFields{
+	"type": optionField,
+})
+
 func NewAddressHistoryRepository(elastic *elastic_cache.Index) AddressHistoryRepository {
 	return &addressHistoryRepository{elastic: elastic}
 }
@@ -68,6 +97,49 @@ func (r *addressHistoryRepository) GetFirstByHash(n network.Network, hash string
 	return r.findOne(results, err)
 }
 
+func (r *addressHistoryRepository) GetLatestByHashAtHeight(n network.Network, hash string, height uint64) (*explorer.AddressHistory, error) {
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewMatchPhraseQuery("hash", hash)).
+		Filter(elastic.NewRangeQuery("height").Lte(height))
+
+	results, err := r.elastic.Client.Search(elastic_cache.AddressHistoryIndex.Get(n)).
+		Query(query).
+		Sort("height", false).
+		Sort("txindex", false).
+		Size(1).
+		Do(context.Background())
+
+	return r.findOne(results, err)
+}
+
+func (r *addressHistoryRepository) GetLatestByHashAtTime(n network.Network, hash string, t time.Time) (*explorer.AddressHistory, error) {
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewMatchPhraseQuery("hash", hash)).
+		Filter(elastic.NewRangeQuery("time").Lte(t))
+
+	results, err := r.elastic.Client.Search(elastic_cache.AddressHistoryIndex.Get(n)).
+		Query(query).
+		Sort("height", false).
+		Sort("txindex", false).
+		Size(1).
+		Do(context.Background())
+
+	return r.findOne(results, err)
+}
+
+func (r *addressHistoryRepository) GetByHashAndTxid(n network.Network, hash string, txid string) (*explorer.AddressHistory, error) {
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewMatchPhraseQuery("hash", hash)).
+		Filter(elastic.NewTermQuery("txid.keyword", txid))
+
+	results, err := r.elastic.Client.Search(elastic_cache.AddressHistoryIndex.Get(n)).
+		Query(query).
+		Size(1).
+		Do(context.Background())
+
+	return r.findOne(results, err)
+}
+
 func (r *addressHistoryRepository) GetCountByHash(n network.Network, hash string) (int64, error) {
 	query := elastic.NewBoolQuery().Filter(elastic.NewMatchPhraseQuery("hash", hash))
 
@@ -207,11 +279,119 @@ func (r *addressHistoryRepository) GetSpendSummary(n network.Network, hash strin
 }
 
 func (r *addressHistoryRepository) GetHistoryByHash(n network.Network, hash string, p framework.Pagination, s framework.Sort, f framework.Filters) ([]*explorer.AddressHistory, int64, error) {
+	service := r.elastic.Client.Search(elastic_cache.AddressHistoryIndex.Get(n))
+	service.Query(historyQuery(hash, f))
+	paginate(service, p, s, &defaultSort{"height", false}, defaultSort{"height", false}, defaultSort{"txindex", false})
+	service.TrackTotalHits(true)
+
+	results, err := service.Do(context.Background())
+	if err != nil {
+		return nil, 0, err
+	}
+	cursors(results, p)
+
+	return r.findMany(results, err)
+}
+
+func (r *addressHistoryRepository) ExportHistoryByHash(n network.Network, hash string, s framework.Sort, f framework.Filters, callback func(history *explorer.AddressHistory) error) error {
+	query := historyQuery(hash, f)
+
+	var searchAfter []interface{}
+	for {
+		service := r.elastic.Client.Search(elastic_cache.AddressHistoryIndex.Get(n))
+		service.Query(query)
+		sort(service, s, &defaultSort{"height", false})
+
+		// search_after needs a unique sort so each history entry is only exported once
+		if !s.IsEmpty() && !s.HasOption("height") {
+			service.Sort("height", false)
+		}
+		if !s.HasOption("txindex") {
+			service.Sort("txindex", false)
+		}
+
+		service.Size(exportBatchSize)
+		if searchAfter != nil {
+			service.SearchAfter(searchAfter...)
+		}
+
+		results, err := service.Do(context.Background())
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range results.Hits.Hits {
+			var history *explorer.AddressHistory
+			if err := json.Unmarshal(hit.Source, &history); err != nil {
+				return err
+			}
+			if err := callback(history); err != nil {
+				return err
+			}
+			searchAfter = hit.Sort
+		}
+
+		if len(results.Hits.Hits) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+// GetHistoryForAddresses calls back with the history of the addresses in the blocks after from up to to, in chain order
+func (r *addressHistoryRepository) GetHistoryForAddresses(n network.Network, from, to uint64, addresses []string, callback func(history *explorer.AddressHistory) error) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, len(addresses))
+	for i, v := range addresses {
+		values[i] = v
+	}
+
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewRangeQuery("height").Gt(from).Lte(to)).
+		Filter(elastic.NewTermsQuery("hash.keyword", values...))
+
+	var searchAfter []interface{}
+	for {
+		service := r.elastic.Client.Search(elastic_cache.AddressHistoryIndex.Get(n)).
+			Query(query).
+			Sort("height", true).
+			Sort("txindex", true).
+			Sort("hash.keyword", true).
+			Size(exportBatchSize)
+		if searchAfter != nil {
+			service.SearchAfter(searchAfter...)
+		}
+
+		results, err := service.Do(context.Background())
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range results.Hits.Hits {
+			var history *explorer.AddressHistory
+			if err := json.Unmarshal(hit.Source, &history); err != nil {
+				return err
+			}
+			if err := callback(history); err != nil {
+				return err
+			}
+			searchAfter = hit.Sort
+		}
+
+		if len(results.Hits.Hits) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+func historyQuery(hash string, f framework.Filters) *elastic.BoolQuery {
 	query := elastic.NewBoolQuery().Filter(elastic.NewMatchPhraseQuery("hash", hash))
 
-	options := f.OnlySupportedOptions([]string{"type"})
-	if option, err := options.Get("type"); err == nil {
-		switch option.Values()[0] {
+	options := f.OnlySupportedOptions(addressHistoryOptionFields.names())
+	if option, err := options.Get("type"); err == nil && len(option.Values()) != 0 {
+		switch option.SingleValue() {
 		case "staking":
 			query.Must(elastic.NewTermQuery("is_stake", true))
 			break
@@ -233,20 +413,8 @@ func (r *addressHistoryRepository) GetHistoryByHash(n network.Network, hash stri
 			break
 		}
 	}
-	service := r.elastic.Client.Search(elastic_cache.AddressHistoryIndex.Get(n))
-	service.Query(query)
-	sort(service, s, &defaultSort{"height", false})
-
-	service.Size(p.Size())
-	service.From(p.From())
-	service.TrackTotalHits(true)
-
-	results, err := service.Do(context.Background())
-	if err != nil {
-		return nil, 0, err
-	}
 
-	return r.findMany(results, err)
+	return filterQuery(query, f, addressHistoryFilterFields)
 }
 
 func (r *addressHistoryRepository) GetAddressGroups(n network.Network, period *group.Period, count int) ([]entity.AddressGroup, error) {
@@ -532,6 +700,57 @@ func (r *addressHistoryRepository) GetStakingRange(n network.Network, from, to u
 	return stakingBlocks, nil
 }
 
+// GetStakers counts the stakes of each address in the blocks after from up to to, most stakes first
+func (r *addressHistoryRepository) GetStakers(n network.Network, from, to uint64, addresses []string) ([]*entity.Staker, error) {
+	zap.S().Infof("AddressHistory: GetStakers(%s, %d, %d)", n.String(), from, to)
+
+	stakers := make([]*entity.Staker, 0)
+	if len(addresses) == 0 {
+		return stakers, nil
+	}
+
+	values := make([]interface{}, len(addresses))
+	for i, v := range addresses {
+		values[i] = v
+	}
+
+	query := elastic.NewBoolQuery().
+		Filter(elastic.NewRangeQuery("height").Gt(from).Lte(to)).
+		Filter(elastic.NewTermsQuery("hash.keyword", values...)).
+		Filter(elastic.NewTermQuery("is_stake", true))
+
+	hashAgg := elastic.NewTermsAggregation().Field("hash.keyword").Size(len(addresses)).OrderByCountDesc()
+	hashAgg.SubAggregation("cold", elastic.NewFilterAggregation().Filter(elastic.NewTermQuery("is_coldstake", true)))
+	hashAgg.SubAggregation("changes", elastic.NewNestedAggregation().Path("changes").
+		SubAggregation("stakable", elastic.NewSumAggregation().Field("changes.stakable")))
+
+	results, err := r.elastic.Client.Search(elastic_cache.AddressHistoryIndex.Get(n)).
+		Query(query).
+		Aggregation("hash", hashAgg).
+		Size(0).
+		Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if hash, found := results.Aggregations.Terms("hash"); found {
+		for _, bucket := range hash.Buckets {
+			staker := &entity.Staker{Address: bucket.Key.(string), Stakes: bucket.DocCount}
+			if cold, found := bucket.Aggregations.Filter("cold"); found {
+				staker.ColdStakes = cold.DocCount
+			}
+			if changes, found := bucket.Aggregations.Nested("changes"); found {
+				if stakable, found := changes.Sum("stakable"); found && stakable.Value != nil {
+					staker.Rewards = int64(*stakable.Value)
+				}
+			}
+			stakers = append(stakers, staker)
+		}
+	}
+
+	return stakers, nil
+}
+
 func (r *addressHistoryRepository) StakingRewardsForAddresses(n network.Network, addresses []string) ([]*entity.StakingReward, error) {
 	values := make([]interface{}, len(addresses))
 	for i, v := range addresses {
@@ -12,6 +12,7 @@ import (
 	"github.com/olivere/elastic/v7"
 	log "github.com/sirupsen/logrus"
 	"go.uber.org/zap"
+	"net/http"
 )
 
 type AddressRepository interface {
@@ -19,18 +20,59 @@ type AddressRepository interface {
 	GetAddressByHash(n network.Network, hash string) (*explorer.Address, error)
 	GetBalancesForAddresses(n network.Network, addresses []string) ([]*explorer.Address, error)
 	GetWealthDistribution(n network.Network, groups []int, totalSupply uint64) ([]*entity.Wealth, error)
+	GetAddressesWithMeta(n network.Network, key string, size, page int) ([]*explorer.Address, int64, error)
+	GetMetaValues(n network.Network, key string, addresses []string) (map[string]string, error)
 	UpdateAddress(n network.Network, address *explorer.Address) error
+	GetAddressMeta(n network.Network, hashes []string) (map[string]*AddressMeta, error)
+	UpdateAddressMeta(n network.Network, updates []*AddressMetaUpdate) ([]error, error)
 }
 
 var (
-	ErrAddressNotFound = errors.New("Address not found")
-	ErrAddressInvalid  = errors.New("Address is invalid")
+	ErrAddressNotFound     = errors.New("Address not found")
+	ErrAddressInvalid      = errors.New("Address is invalid")
+	ErrAddressMetaConflict = errors.New("Address meta was changed by another request, retry the update")
 )
 
+// AddressMeta is the meta of an address document with the sequence number and primary term it was read at,
+// so an update of it fails when the document has changed since
+type AddressMeta struct {
+	Id          string
+	Hash        string
+	Meta        map[string]string
+	SeqNo       int64
+	PrimaryTerm int64
+}
+
+// AddressMetaUpdate sets and removes keys of the address meta, leaving its other keys
+type AddressMetaUpdate struct {
+	Meta   *AddressMeta
+	Set    map[string]string
+	Remove []string
+}
+
+const updateAddressMetaScript = `if (ctx._source.meta == null) { ctx._source.meta = new HashMap(); }
+for (key in params.remove) { ctx._source.meta.remove(key); }
+ctx._source.meta.putAll(params.set);`
+
 type addressRepository struct {
 	elastic *elastic_cache.Index
 }
 
+var addressFilterFields = registerFilterFields(AddressFilters, filterFields{
+	"height":        valueField(""),
+	"spendable":     valueField(""),
+	"stakable":      valueField(""),
+	"voting_weight": valueField(""),
+	"created_time":  valueField(""),
+	"created_block": valueField(""),
+	"meta.label":    valueField("meta"),
+})
+
+// addressOptionFields are the filter options GetAddresses reads itself
+var addressOptionFields = registerFilterFields(AddressFilters, filterFields{
+	"exclude": optionField,
+})
+
 func NewAddressRepository(elastic *elastic_cache.Index) AddressRepository {
 	return &addressRepository{elastic: elastic}
 }
@@ -41,24 +83,28 @@ func (r *addressRepository) GetAddresses(n network.Network, size, page int, f fr
 		Size(size).
 		TrackTotalHits(true)
 
-	options := f.OnlySupportedOptions([]string{"exclude"})
-	if option, err := options.Get("exclude"); err == nil {
+	query := elastic.NewBoolQuery()
+
+	options := f.OnlySupportedOptions(addressOptionFields.names())
+	if option, err := options.Get("exclude"); err == nil && len(option.Values()) != 0 {
 		zap.L().Info("Has the exclude filter")
-		switch option.Values()[0] {
+		switch option.SingleValue() {
 		case "empty":
 			if s.HasOption("spendable") {
-				service.Query(elastic.NewRangeQuery("spendable").Gt(0))
+				query.Filter(elastic.NewRangeQuery("spendable").Gt(0))
 			}
 			if s.HasOption("stakable") {
-				service.Query(elastic.NewRangeQuery("stakable").Gt(0))
+				query.Filter(elastic.NewRangeQuery("stakable").Gt(0))
 			}
 			if s.HasOption("voting_weight") {
-				service.Query(elastic.NewRangeQuery("voting_weight").Gt(0))
+				query.Filter(elastic.NewRangeQuery("voting_weight").Gt(0))
 			}
 			break
 		}
 	}
 
+	service.Query(filterQuery(query, f, addressFilterFields))
+
 	sort(service, s, &defaultSort{"spendable", false})
 
 	results, err := service.Do(context.Background())
@@ -154,6 +200,142 @@ func (r *addressRepository) UpdateAddress(n network.Network, address *explorer.A
 	return err
 }
 
+// GetAddressMeta reads the meta of each of the addresses which exists, by hash
+func (r *addressRepository) GetAddressMeta(n network.Network, hashes []string) (map[string]*AddressMeta, error) {
+	values := make([]interface{}, len(hashes))
+	for i, v := range hashes {
+		values[i] = v
+	}
+
+	results, err := r.elastic.Client.Search(elastic_cache.AddressIndex.Get(n)).
+		Query(elastic.NewTermsQuery("hash.keyword", values...)).
+		FetchSourceContext(elastic.NewFetchSourceContext(true).Include("hash", "meta")).
+		SeqNoPrimaryTerm(true).
+		Size(len(hashes)).
+		Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	metas := make(map[string]*AddressMeta)
+	for _, hit := range results.Hits.Hits {
+		var address explorer.Address
+		if err := json.Unmarshal(hit.Source, &address); err != nil {
+			return nil, err
+		}
+		if hit.SeqNo == nil || hit.PrimaryTerm == nil {
+			return nil, errors.New("Address meta was read without its sequence number")
+		}
+		if address.Meta == nil {
+			address.Meta = map[string]string{}
+		}
+
+		metas[address.Hash] = &AddressMeta{
+			Id:          hit.Id,
+			Hash:        address.Hash,
+			Meta:        address.Meta,
+			SeqNo:       *hit.SeqNo,
+			PrimaryTerm: *hit.PrimaryTerm,
+		}
+	}
+
+	return metas, nil
+}
+
+// UpdateAddressMeta writes the updates in one bulk request with a single refresh, changing only their keys so
+// the balances written by the indexer are kept. An update of meta which has changed since it was read fails with
+// ErrAddressMetaConflict. The error of each update is returned by its index, the error is for the whole request.
+func (r *addressRepository) UpdateAddressMeta(n network.Network, updates []*AddressMetaUpdate) ([]error, error) {
+	bulk := r.elastic.Client.Bulk().Index(elastic_cache.AddressIndex.Get(n)).Refresh("wait_for")
+	for _, update := range updates {
+		set := update.Set
+		if set == nil {
+			set = map[string]string{}
+		}
+		remove := update.Remove
+		if remove == nil {
+			remove = []string{}
+		}
+
+		bulk.Add(elastic.NewBulkUpdateRequest().
+			Id(update.Meta.Id).
+			IfSeqNo(update.Meta.SeqNo).
+			IfPrimaryTerm(update.Meta.PrimaryTerm).
+			Script(elastic.NewScript(updateAddressMetaScript).Param("set", set).Param("remove", remove)))
+	}
+
+	response, err := bulk.Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(updates))
+	for i, item := range response.Items {
+		for _, result := range item {
+			switch {
+			case result.Status == http.StatusConflict:
+				errs[i] = ErrAddressMetaConflict
+			case result.Error != nil:
+				errs[i] = errors.New(result.Error.Reason)
+			}
+		}
+	}
+
+	return errs, nil
+}
+
+// GetAddressesWithMeta returns the addresses with the meta key, or with any meta key when it is empty.
+// The meta is a nested field so it is only matched by a nested query.
+func (r *addressRepository) GetAddressesWithMeta(n network.Network, key string, size, page int) ([]*explorer.Address, int64, error) {
+	field := "meta.*"
+	if key != "" {
+		field = "meta." + key
+	}
+
+	results, err := r.elastic.Client.Search(elastic_cache.AddressIndex.Get(n)).
+		Query(elastic.NewBoolQuery().Filter(elastic.NewNestedQuery("meta", elastic.NewExistsQuery(field)))).
+		Sort("spendable", false).
+		From((page * size) - size).
+		Size(size).
+		TrackTotalHits(true).
+		Do(context.Background())
+
+	return r.findMany(results, err)
+}
+
+// GetMetaValues returns the value of the meta key for each of the addresses which has it.
+// The values are read from the source, as the dynamic keyword of a key ignores values over 256 characters.
+func (r *addressRepository) GetMetaValues(n network.Network, key string, addresses []string) (map[string]string, error) {
+	values := make([]interface{}, len(addresses))
+	for i, v := range addresses {
+		values[i] = v
+	}
+
+	results, err := r.elastic.Client.Search(elastic_cache.AddressIndex.Get(n)).
+		Query(elastic.NewBoolQuery().
+			Filter(elastic.NewTermsQuery("hash.keyword", values...)).
+			Filter(elastic.NewNestedQuery("meta", elastic.NewExistsQuery("meta."+key)))).
+		FetchSourceContext(elastic.NewFetchSourceContext(true).Include("hash", "meta."+key)).
+		Size(len(addresses)).
+		Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	metaValues := make(map[string]string)
+	for _, hit := range results.Hits.Hits {
+		var address explorer.Address
+		if err := json.Unmarshal(hit.Source, &address); err != nil {
+			return nil, err
+		}
+		if value, ok := address.Meta[key]; ok {
+			metaValues[address.Hash] = value
+		}
+	}
+
+	return metaValues, nil
+}
+
 func (r *addressRepository) populateRichListPosition(n network.Network, address *explorer.Address) error {
 	spendable, err := r.elastic.Client.Count(elastic_cache.AddressIndex.Get(n)).
 		Query(elastic.NewRangeQuery("spendable").Gt(address.Spendable)).
@@ -6,6 +6,7 @@ import (
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block/entity"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
 	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"time"
 )
 
 type cachingBlockRepository struct {
@@ -74,3 +75,11 @@ func (r *cachingBlockRepository) GetSupply(n network.Network, blocks int, fillEm
 func (r *cachingBlockRepository) GetStakingAddresses(n network.Network, from, to uint64) ([]string, error) {
 	return r.repository.GetStakingAddresses(n, from, to)
 }
+
+func (r *cachingBlockRepository) GetHeightAtTime(n network.Network, t time.Time) (uint64, error) {
+	return r.repository.GetHeightAtTime(n, t)
+}
+
+func (r *cachingBlockRepository) GetTimesAtHeights(n network.Network, heights []uint64) (map[uint64]time.Time, error) {
+	return r.repository.GetTimesAtHeights(n, heights)
+}
@@ -28,6 +28,8 @@ type BlockRepository interface {
 	GetFeesForLastBlocks(n network.Network, blocks int) (fees float64, err error)
 	GetSupply(n network.Network, blocks int, fillEmpty bool) (supply []entity.Supply, err error)
 	GetStakingAddresses(n network.Network, from, to uint64) ([]string, error)
+	GetHeightAtTime(n network.Network, t time.Time) (uint64, error)
+	GetTimesAtHeights(n network.Network, heights []uint64) (map[uint64]time.Time, error)
 }
 
 var (
@@ -38,6 +40,30 @@ type blockRepository struct {
 	elastic *elastic_cache.Index
 }
 
+var blockFilterFields = registerFilterFields(BlockFilters, filterFields{
+	"height":                 valueField(""),
+	"time":                   valueField(""),
+	"mediantime":             valueField(""),
+	"size":                   valueField(""),
+	"strippedsize":           valueField(""),
+	"weight":                 valueField(""),
+	"version":                valueField(""),
+	"nonce":                  valueField(""),
+	"tx_count":               valueField(""),
+	"stake":                  valueField(""),
+	"spend":                  valueField(""),
+	"fees":                   valueField(""),
+	"cfundPayout":            valueField(""),
+	"block_cycle.cycle":      valueField("block_cycle"),
+	"block_cycle.index":      valueField("block_cycle"),
+	"supply_balance.public":  valueField("supply_balance"),
+	"supply_balance.private": valueField("supply_balance"),
+	"supply_balance.wrapped": valueField("supply_balance"),
+	"supply_change.public":   valueField("supply_change"),
+	"supply_change.private":  valueField("supply_change"),
+	"supply_change.wrapped":  valueField("supply_change"),
+})
+
 func NewBlockRepository(elastic *elastic_cache.Index) BlockRepository {
 	return &blockRepository{elastic: elastic}
 }
@@ -58,21 +84,21 @@ func (r *blockRepository) GetBestBlock(n network.Network) (*explorer.Block, erro
 
 func (r *blockRepository) GetBlocks(n network.Network, p framework.Pagination, s framework.Sort, f framework.Filters, bestBlock *explorer.Block) ([]*explorer.Block, int64, error) {
 	service := r.elastic.Client.Search(elastic_cache.BlockIndex.Get(n))
-	sort(service, s, &defaultSort{"height", false})
+	service.Query(filterQuery(elastic.NewBoolQuery(), f, blockFilterFields))
+	paginate(service, p, s, &defaultSort{"height", false}, defaultSort{"height", false})
 
 	//from := int(bestBlock.Height+1) - ((p.Page() - 1) * p.Size())
 	//if from <= 0 {
 	//	from = p.Size()
 	//}
 
-	service.Size(p.Size())
-	service.From(p.From())
 	service.TrackTotalHits(true)
 
 	results, err := service.Do(context.Background())
 	if err != nil {
 		return nil, 0, err
 	}
+	cursors(results, p)
 
 	var blocks = make([]*explorer.Block, 0)
 	for _, hit := range results.Hits.Hits {
@@ -430,6 +456,54 @@ func (r *blockRepository) GetStakingAddresses(n network.Network, from, to uint64
 	return addresses, err
 }
 
+// GetHeightAtTime returns the height of the last block at or before t
+func (r *blockRepository) GetHeightAtTime(n network.Network, t time.Time) (uint64, error) {
+	results, err := r.elastic.Client.Search(elastic_cache.BlockIndex.Get(n)).
+		Query(elastic.NewRangeQuery("time").Lte(t)).
+		Sort("height", false).
+		Size(1).
+		Do(context.Background())
+
+	block, err := r.findOne(results, err)
+	if err != nil {
+		return 0, err
+	}
+
+	return block.Height, nil
+}
+
+// GetTimesAtHeights returns the time of each block found at the heights
+func (r *blockRepository) GetTimesAtHeights(n network.Network, heights []uint64) (map[uint64]time.Time, error) {
+	times := make(map[uint64]time.Time)
+	if len(heights) == 0 {
+		return times, nil
+	}
+
+	values := make([]interface{}, len(heights))
+	for i, height := range heights {
+		values[i] = height
+	}
+
+	results, err := r.elastic.Client.Search(elastic_cache.BlockIndex.Get(n)).
+		Query(elastic.NewTermsQuery("height", values...)).
+		FetchSourceContext(elastic.NewFetchSourceContext(true).Include("height", "time")).
+		Size(len(heights)).
+		Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hit := range results.Hits.Hits {
+		var block explorer.Block
+		if err := json.Unmarshal(hit.Source, &block); err != nil {
+			return nil, err
+		}
+		times[block.Height] = block.Time
+	}
+
+	return times, nil
+}
+
 func (r *blockRepository) findOne(results *elastic.SearchResult, err error) (*explorer.Block, error) {
 	if err != nil || results.TotalHits() == 0 {
 		err = ErrBlockNotFound
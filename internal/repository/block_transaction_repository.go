@@ -19,12 +19,35 @@ type BlockTransactionRepository interface {
 	GetTransactionByHash(n network.Network, hash string) (*explorer.BlockTransaction, error)
 	GetRawTransactionByHash(n network.Network, hash string) (*explorer.RawBlockTransaction, error)
 	GetAssociatedStakingAddresses(n network.Network, address string) ([]string, error)
+	GetUnspentTransactions(n network.Network, addresses []string) ([]*explorer.BlockTransaction, error)
+	GetTransactionsByTxids(n network.Network, txids []string) ([]*explorer.BlockTransaction, error)
+	GetOutputSummary(n network.Network, address string) (fundedCount, fundedSum, spentCount, spentSum int64, err error)
 }
 
 type blockTransactionRepository struct {
 	elastic *elastic_cache.Index
 }
 
+var transactionFilterFields = registerFilterFields(TransactionFilters, filterFields{
+	"height":    valueField(""),
+	"time":      valueField(""),
+	"blocktime": valueField(""),
+	"index":     valueField(""),
+	"size":      valueField(""),
+	"vsize":     valueField(""),
+	"version":   valueField(""),
+	"locktime":  valueField(""),
+	"stake":     valueField(""),
+	"spend":     valueField(""),
+	"fees":      valueField(""),
+})
+
+// transactionOptionFields are the filter options GetTransactions reads itself
+var transactionOptionFields = registerFilterFields(TransactionFilters, filterFields{
+	"type":    optionField,
+	"wOrXNav": optionField,
+})
+
 func NewBlockTransactionRepository(elastic *elastic_cache.Index) BlockTransactionRepository {
 	return &blockTransactionRepository{elastic: elastic}
 }
@@ -36,12 +59,12 @@ func (r *blockTransactionRepository) Count(n network.Network) (int64, error) {
 
 func (r *blockTransactionRepository) GetTransactions(n network.Network, p framework.Pagination, s framework.Sort, f framework.Filters) ([]*explorer.BlockTransaction, int64, error) {
 	query := elastic.NewBoolQuery()
-	options := f.OnlySupportedOptions([]string{"type", "wOrXNav"})
-	if option, err := options.Get("type"); err == nil {
+	options := f.OnlySupportedOptions(transactionOptionFields.names())
+	if option, err := options.Get("type"); err == nil && len(option.Values()) != 0 {
 		query = query.Must(elastic.NewTermsQuery("type", option.Values()...))
 	}
 
-	if wOrXNav, err := options.Get("wOrXNav"); err == nil {
+	if wOrXNav, err := options.Get("wOrXNav"); err == nil && len(wOrXNav.Values()) != 0 {
 		value := fmt.Sprintf("%v", wOrXNav.SingleValue())
 		if value == "Nav" {
 			query = query.MustNot(elastic.NewTermQuery("wrapped", true))
@@ -54,17 +77,15 @@ func (r *blockTransactionRepository) GetTransactions(n network.Network, p framew
 	}
 
 	service := r.elastic.Client.Search(elastic_cache.BlockTransactionIndex.Get(n))
-	service.Query(query)
-	sort(service, s, &defaultSort{"txheight", false})
-
-	service.Size(p.Size())
-	service.From(p.From())
+	service.Query(filterQuery(query, f, transactionFilterFields))
+	paginate(service, p, s, &defaultSort{"txheight", false}, defaultSort{"height", false}, defaultSort{"index", false})
 	service.TrackTotalHits(true)
 
 	results, err := service.Do(context.Background())
 	if err != nil {
 		return nil, 0, err
 	}
+	cursors(results, p)
 
 	var txs = make([]*explorer.BlockTransaction, 0)
 	for _, hit := range results.Hits.Hits {
@@ -145,6 +166,129 @@ func (r *blockTransactionRepository) GetAssociatedStakingAddresses(n network.Net
 	return stakingAddresses, err
 }
 
+// GetUnspentTransactions returns every transaction with an unredeemed output to one of the addresses, oldest first
+func (r *blockTransactionRepository) GetUnspentTransactions(n network.Network, addresses []string) ([]*explorer.BlockTransaction, error) {
+	values := make([]interface{}, 0, len(addresses))
+	for _, address := range addresses {
+		values = append(values, address)
+	}
+
+	voutQuery := elastic.NewBoolQuery().
+		Filter(elastic.NewTermsQuery("vout.scriptPubKey.addresses.keyword", values...)).
+		Filter(elastic.NewTermQuery("vout.redeemed", false))
+	query := elastic.NewNestedQuery("vout", voutQuery)
+
+	txs := make([]*explorer.BlockTransaction, 0)
+	var searchAfter []interface{}
+	for {
+		service := r.elastic.Client.Search(elastic_cache.BlockTransactionIndex.Get(n)).
+			Query(query).
+			Sort("height", true).
+			Sort("index", true).
+			Size(exportBatchSize)
+		if searchAfter != nil {
+			service.SearchAfter(searchAfter...)
+		}
+
+		results, err := service.Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range results.Hits.Hits {
+			var tx *explorer.BlockTransaction
+			if err := json.Unmarshal(hit.Source, &tx); err != nil {
+				return nil, err
+			}
+			txs = append(txs, tx)
+			searchAfter = hit.Sort
+		}
+
+		if len(results.Hits.Hits) < exportBatchSize {
+			return txs, nil
+		}
+	}
+}
+
+// GetTransactionsByTxids returns the transactions found of the txids in batches, in no particular order
+func (r *blockTransactionRepository) GetTransactionsByTxids(n network.Network, txids []string) ([]*explorer.BlockTransaction, error) {
+	txs := make([]*explorer.BlockTransaction, 0, len(txids))
+	for from := 0; from < len(txids); from += exportBatchSize {
+		to := from + exportBatchSize
+		if to > len(txids) {
+			to = len(txids)
+		}
+
+		values := make([]interface{}, 0, to-from)
+		for _, txid := range txids[from:to] {
+			values = append(values, txid)
+		}
+
+		results, err := r.elastic.Client.Search(elastic_cache.BlockTransactionIndex.Get(n)).
+			Query(elastic.NewTermsQuery("txid.keyword", values...)).
+			Size(len(values)).
+			Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range results.Hits.Hits {
+			var tx *explorer.BlockTransaction
+			if err := json.Unmarshal(hit.Source, &tx); err != nil {
+				return nil, err
+			}
+			txs = append(txs, tx)
+		}
+	}
+
+	return txs, nil
+}
+
+// GetOutputSummary counts and sums the outputs paying the address, and those of them that were redeemed
+func (r *blockTransactionRepository) GetOutputSummary(n network.Network, address string) (fundedCount, fundedSum, spentCount, spentSum int64, err error) {
+	addressQuery := elastic.NewTermQuery("vout.scriptPubKey.addresses.keyword", address)
+
+	spentAgg := elastic.NewFilterAggregation().Filter(elastic.NewTermQuery("vout.redeemed", true))
+	spentAgg.SubAggregation("sum", elastic.NewSumAggregation().Field("vout.valuesat"))
+
+	fundedAgg := elastic.NewFilterAggregation().Filter(addressQuery)
+	fundedAgg.SubAggregation("sum", elastic.NewSumAggregation().Field("vout.valuesat"))
+	fundedAgg.SubAggregation("spent", spentAgg)
+
+	voutAgg := elastic.NewNestedAggregation().Path("vout")
+	voutAgg.SubAggregation("funded", fundedAgg)
+
+	results, err := r.elastic.Client.Search(elastic_cache.BlockTransactionIndex.Get(n)).
+		Query(elastic.NewNestedQuery("vout", addressQuery)).
+		Aggregation("vout", voutAgg).
+		Size(0).
+		Do(context.Background())
+	if err != nil {
+		return
+	}
+
+	vout, found := results.Aggregations.Nested("vout")
+	if !found {
+		return
+	}
+	funded, found := vout.Filter("funded")
+	if !found {
+		return
+	}
+	fundedCount = funded.DocCount
+	if sum, found := funded.Sum("sum"); found && sum.Value != nil {
+		fundedSum = int64(*sum.Value)
+	}
+	if spent, found := funded.Filter("spent"); found {
+		spentCount = spent.DocCount
+		if sum, found := spent.Sum("sum"); found && sum.Value != nil {
+			spentSum = int64(*sum.Value)
+		}
+	}
+
+	return
+}
+
 func (r *blockTransactionRepository) findOne(results *elastic.SearchResult, err error) (*explorer.BlockTransaction, error) {
 	if err != nil || results.TotalHits() == 0 {
 		err = ErrBlockNotFound
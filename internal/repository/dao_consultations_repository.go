@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/elastic_cache"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/entity"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
 	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
 	"github.com/olivere/elastic/v7"
@@ -12,9 +13,11 @@ import (
 
 type DaoConsultationRepository interface {
 	GetConsultations(n network.Network, status *explorer.ConsultationStatus, consensus *bool, min *uint, asc bool, size, page int) ([]*explorer.Consultation, int64, error)
+	SearchConsultations(n network.Network, q string, status *explorer.ConsultationStatus, consensus *bool, min *uint, size, page int) ([]*entity.ConsultationMatch, int64, error)
 	GetConsultation(n network.Network, hash string) (*explorer.Consultation, error)
 	GetAnswer(n network.Network, hash string) (*explorer.Answer, error)
 	GetConsensusConsultations(n network.Network, dir bool, size, page int) ([]*explorer.Consultation, int64, error)
+	GetConsultationsUpdatedSince(n network.Network, height uint64) ([]*explorer.Consultation, error)
 }
 
 type daoConsultationRepository struct {
@@ -31,16 +34,7 @@ func NewDaoConsultationRepository(elastic *elastic_cache.Index) DaoConsultationR
 }
 
 func (r *daoConsultationRepository) GetConsultations(n network.Network, status *explorer.ConsultationStatus, consensus *bool, min *uint, asc bool, size, page int) ([]*explorer.Consultation, int64, error) {
-	query := elastic.NewBoolQuery()
-	if status != nil {
-		query = query.Must(elastic.NewTermQuery("state", status.State))
-	}
-	if min != nil {
-		query = query.Must(elastic.NewTermQuery("min", min))
-	}
-	if consensus != nil {
-		query = query.Must(elastic.NewTermQuery("consensusParameter", consensus))
-	}
+	query := consultationQuery(elastic.NewBoolQuery(), status, consensus, min)
 
 	result, err := r.elastic.Client.Search(elastic_cache.DaoConsultationIndex.Get(n)).
 		Query(query).
@@ -55,6 +49,64 @@ func (r *daoConsultationRepository) GetConsultations(n network.Network, status *
 	return r.findMany(result, err)
 }
 
+// SearchConsultations full-text searches consultation questions and answers, best matches first
+func (r *daoConsultationRepository) SearchConsultations(n network.Network, q string, status *explorer.ConsultationStatus, consensus *bool, min *uint, size, page int) ([]*entity.ConsultationMatch, int64, error) {
+	answersQuery := elastic.NewNestedQuery("answers", elastic.NewMatchQuery("answers.answer", q)).
+		InnerHit(elastic.NewInnerHit().Highlight(highlight("answers.answer")).Size(3))
+
+	query := elastic.NewBoolQuery().
+		Should(elastic.NewMatchQuery("question", q), answersQuery).
+		MinimumNumberShouldMatch(1)
+	query = consultationQuery(query, status, consensus, min)
+
+	results, err := r.elastic.Client.Search(elastic_cache.DaoConsultationIndex.Get(n)).
+		Query(query).
+		Highlight(highlight("question")).
+		SortBy(elastic.NewScoreSort(), elastic.NewFieldSort("height").Desc()).
+		From((page * size) - size).
+		Size(size).
+		TrackTotalHits(true).
+		Do(context.Background())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	consultations := make([]*entity.ConsultationMatch, 0)
+	for _, hit := range results.Hits.Hits {
+		var consultation *explorer.Consultation
+		if err := json.Unmarshal(hit.Source, &consultation); err != nil {
+			continue
+		}
+
+		match := &entity.ConsultationMatch{Consultation: consultation, Highlight: hit.Highlight}
+		if match.Highlight == nil {
+			match.Highlight = make(map[string][]string)
+		}
+		if answers, ok := hit.InnerHits["answers"]; ok && answers.Hits != nil {
+			for _, answer := range answers.Hits.Hits {
+				match.Highlight["answers.answer"] = append(match.Highlight["answers.answer"], answer.Highlight["answers.answer"]...)
+			}
+		}
+		consultations = append(consultations, match)
+	}
+
+	return consultations, results.TotalHits(), nil
+}
+
+func consultationQuery(query *elastic.BoolQuery, status *explorer.ConsultationStatus, consensus *bool, min *uint) *elastic.BoolQuery {
+	if status != nil {
+		query = query.Filter(elastic.NewTermQuery("state", status.State))
+	}
+	if min != nil {
+		query = query.Filter(elastic.NewTermQuery("min", min))
+	}
+	if consensus != nil {
+		query = query.Filter(elastic.NewTermQuery("consensusParameter", consensus))
+	}
+
+	return query
+}
+
 func (r *daoConsultationRepository) GetConsultation(n network.Network, hash string) (*explorer.Consultation, error) {
 	results, err := r.elastic.Client.Search(elastic_cache.DaoConsultationIndex.Get(n)).
 		Query(elastic.NewTermQuery("hash.keyword", hash)).
@@ -132,3 +184,22 @@ func (r *daoConsultationRepository) findMany(results *elastic.SearchResult, err
 
 	return consultations, results.TotalHits(), err
 }
+
+// GetConsultationsUpdatedSince returns the consultations updated after the height
+func (r *daoConsultationRepository) GetConsultationsUpdatedSince(n network.Network, height uint64) ([]*explorer.Consultation, error) {
+	consultations := make([]*explorer.Consultation, 0)
+	err := updatedSince(r.elastic.Client, elastic_cache.DaoConsultationIndex.Get(n), height, func(hit *elastic.SearchHit) error {
+		var consultation *explorer.Consultation
+		if err := json.Unmarshal(hit.Source, &consultation); err != nil {
+			return err
+		}
+		consultations = append(consultations, consultation)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return consultations, nil
+}
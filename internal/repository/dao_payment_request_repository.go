@@ -16,6 +16,7 @@ type DaoPaymentRequestRepository interface {
 	GetPaymentRequestsForProposal(n network.Network, proposal *explorer.Proposal) ([]*explorer.PaymentRequest, error)
 	GetPaymentRequest(n network.Network, hash string) (*explorer.PaymentRequest, error)
 	GetValuePaid(n network.Network) (*float64, error)
+	GetPaymentRequestsUpdatedSince(n network.Network, height uint64) ([]*explorer.PaymentRequest, error)
 }
 
 type daoPaymentRequestRepository struct {
@@ -129,3 +130,22 @@ func (r *daoPaymentRequestRepository) findMany(results *elastic.SearchResult, er
 
 	return paymentRequests, results.TotalHits(), err
 }
+
+// GetPaymentRequestsUpdatedSince returns the payment requests updated after the height
+func (r *daoPaymentRequestRepository) GetPaymentRequestsUpdatedSince(n network.Network, height uint64) ([]*explorer.PaymentRequest, error) {
+	paymentRequests := make([]*explorer.PaymentRequest, 0)
+	err := updatedSince(r.elastic.Client, elastic_cache.PaymentRequestIndex.Get(n), height, func(hit *elastic.SearchHit) error {
+		var paymentRequest *explorer.PaymentRequest
+		if err := json.Unmarshal(hit.Source, &paymentRequest); err != nil {
+			return err
+		}
+		paymentRequests = append(paymentRequests, paymentRequest)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paymentRequests, nil
+}
@@ -13,10 +13,12 @@ import (
 )
 
 type DaoProposalRepository interface {
-	GetProposals(n network.Network, status *explorer.ProposalStatus, dir bool, size int, page int) ([]*explorer.Proposal, int64, error)
+	GetProposals(n network.Network, status *explorer.ProposalStatus, byState bool, dir bool, size int, page int) ([]*explorer.Proposal, int64, error)
 	GetLegacyProposals(n network.Network, status *explorer.ProposalStatus, dir bool, size int, page int) ([]*entity.LegacyProposal, int64, error)
+	SearchProposals(n network.Network, q string, status *explorer.ProposalStatus, size int, page int) ([]*entity.ProposalMatch, int64, error)
 	GetProposal(n network.Network, hash string) (*explorer.Proposal, error)
 	GetValueLocked(n network.Network) (*float64, error)
+	GetProposalsUpdatedSince(n network.Network, height uint64) ([]*explorer.Proposal, error)
 }
 
 type daoProposalRepository struct {
@@ -31,19 +33,16 @@ func NewDaoProposalRepository(elastic *elastic_cache.Index) DaoProposalRepositor
 	return &daoProposalRepository{elastic: elastic}
 }
 
-func (r *daoProposalRepository) GetProposals(n network.Network, status *explorer.ProposalStatus, dir bool, size int, page int) ([]*explorer.Proposal, int64, error) {
-	query := elastic.NewBoolQuery()
-	if *status == explorer.ProposalAccepted {
-		query = query.Should(elastic.NewTermQuery("status.keyword", status.Status))
-		query = query.Should(elastic.NewTermQuery("status.keyword", explorer.ProposalPendingVotingPreq.Status))
-		query = query.Should(elastic.NewTermQuery("status.keyword", explorer.ProposalAcceptedExpired.Status))
-	} else {
-		query = query.Must(elastic.NewTermQuery("status.keyword", status.Status))
+// GetProposals orders the proposals by height, grouped by state when byState is set
+func (r *daoProposalRepository) GetProposals(n network.Network, status *explorer.ProposalStatus, byState bool, dir bool, size int, page int) ([]*explorer.Proposal, int64, error) {
+	query := proposalStatusQuery(elastic.NewBoolQuery(), status)
+
+	service := r.elastic.Client.Search(elastic_cache.ProposalIndex.Get(n)).Query(query)
+	if byState {
+		service.Sort("state.keyword", !dir)
 	}
 
-	results, err := r.elastic.Client.Search(elastic_cache.ProposalIndex.Get(n)).
-		Query(query).
-		Sort("state.keyword", !dir).
+	results, err := service.
 		Sort("height", dir).
 		From((page * size) - size).
 		Size(size).
@@ -55,6 +54,53 @@ func (r *daoProposalRepository) GetProposals(n network.Network, status *explorer
 	return r.findMany(results, err)
 }
 
+// SearchProposals full-text searches proposal descriptions, best matches first
+func (r *daoProposalRepository) SearchProposals(n network.Network, q string, status *explorer.ProposalStatus, size int, page int) ([]*entity.ProposalMatch, int64, error) {
+	query := elastic.NewBoolQuery().Must(elastic.NewMatchQuery("description", q))
+	if status != nil {
+		query = query.Filter(proposalStatusQuery(elastic.NewBoolQuery(), status))
+	}
+
+	results, err := r.elastic.Client.Search(elastic_cache.ProposalIndex.Get(n)).
+		Query(query).
+		Highlight(highlight("description")).
+		SortBy(elastic.NewScoreSort(), elastic.NewFieldSort("height").Desc()).
+		From((page * size) - size).
+		Size(size).
+		TrackTotalHits(true).
+		Do(context.Background())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	proposals := make([]*entity.ProposalMatch, 0)
+	for _, hit := range results.Hits.Hits {
+		var proposal *explorer.Proposal
+		if err := json.Unmarshal(hit.Source, &proposal); err == nil {
+			proposals = append(proposals, &entity.ProposalMatch{Proposal: proposal, Highlight: hit.Highlight})
+		}
+	}
+
+	return proposals, results.TotalHits(), nil
+}
+
+// proposalStatusQuery matches the status, accepted also matches proposals accepted and waiting or expired
+func proposalStatusQuery(query *elastic.BoolQuery, status *explorer.ProposalStatus) *elastic.BoolQuery {
+	if status == nil {
+		return query
+	}
+
+	if *status == explorer.ProposalAccepted {
+		query = query.Should(elastic.NewTermQuery("status.keyword", status.Status))
+		query = query.Should(elastic.NewTermQuery("status.keyword", explorer.ProposalPendingVotingPreq.Status))
+		query = query.Should(elastic.NewTermQuery("status.keyword", explorer.ProposalAcceptedExpired.Status))
+	} else {
+		query = query.Must(elastic.NewTermQuery("status.keyword", status.Status))
+	}
+
+	return query
+}
+
 func (r *daoProposalRepository) GetLegacyProposals(n network.Network, status *explorer.ProposalStatus, dir bool, size int, page int) ([]*entity.LegacyProposal, int64, error) {
 	query := elastic.NewBoolQuery()
 	if status != nil {
@@ -149,3 +195,22 @@ func (r *daoProposalRepository) findMany(results *elastic.SearchResult, err erro
 
 	return proposals, results.TotalHits(), err
 }
+
+// GetProposalsUpdatedSince returns the proposals updated after the height
+func (r *daoProposalRepository) GetProposalsUpdatedSince(n network.Network, height uint64) ([]*explorer.Proposal, error) {
+	proposals := make([]*explorer.Proposal, 0)
+	err := updatedSince(r.elastic.Client, elastic_cache.ProposalIndex.Get(n), height, func(hit *elastic.SearchHit) error {
+		var proposal *explorer.Proposal
+		if err := json.Unmarshal(hit.Source, &proposal); err != nil {
+			return err
+		}
+		proposals = append(proposals, proposal)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
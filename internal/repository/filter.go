@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/olivere/elastic/v7"
+)
+
+// filterField is the nested path of a field which may be filtered on, or "" when the field is not nested,
+// and the operators it supports
+type filterField struct {
+	framework.FilterField
+	path string
+}
+
+// valueField is filtered by filterQuery, which supports every operator and negation
+func valueField(path string) filterField {
+	return filterField{framework.FilterField{Operators: framework.FilterOperators, Negatable: true}, path}
+}
+
+// optionField is read by its repository as a single value the field equals
+var optionField = filterField{FilterField: framework.FilterField{Operators: []framework.FilterOperator{framework.FilterEquals}}}
+
+// The resources whose filters are declared, which framework.Filterable checks a route's filters against
+const (
+	BlockFilters          = "block"
+	TransactionFilters    = "transaction"
+	AddressFilters        = "address"
+	AddressHistoryFilters = "address_history"
+)
+
+type filterFields map[string]filterField
+
+// registerFilterFields declares the fields of the resource to the filter parser, which rejects the fields and operators it does not support
+func registerFilterFields(resource string, fields filterFields) filterFields {
+	for name, field := range fields {
+		framework.RegisterFilterField(resource, name, field.FilterField)
+	}
+
+	return fields
+}
+
+func (ff filterFields) names() []string {
+	names := make([]string, 0, len(ff))
+	for name := range ff {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// filterQuery adds the filter options for the supported fields to the bool query
+func filterQuery(query *elastic.BoolQuery, f framework.Filters, fields filterFields) *elastic.BoolQuery {
+	for _, option := range f.OnlySupportedOptions(fields.names()) {
+		clause := filterClause(option)
+		if path := fields[option.Field()].path; path != "" {
+			clause = elastic.NewNestedQuery(path, clause)
+		}
+
+		if option.Negated() {
+			query.MustNot(clause)
+		} else {
+			query.Filter(clause)
+		}
+	}
+
+	return query
+}
+
+func filterClause(option framework.FilterOption) elastic.Query {
+	switch option.Operator() {
+	case framework.FilterGreaterThan:
+		return elastic.NewRangeQuery(option.Field()).Gt(option.SingleValue())
+	case framework.FilterGreaterThanOrEqual:
+		return elastic.NewRangeQuery(option.Field()).Gte(option.SingleValue())
+	case framework.FilterLessThan:
+		return elastic.NewRangeQuery(option.Field()).Lt(option.SingleValue())
+	case framework.FilterLessThanOrEqual:
+		return elastic.NewRangeQuery(option.Field()).Lte(option.SingleValue())
+	case framework.FilterExists:
+		return elastic.NewExistsQuery(option.Field())
+	}
+
+	return elastic.NewTermsQuery(option.Field(), option.Values()...)
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/elastic_cache"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+)
+
+type HealthRepository interface {
+	ClusterStatus(ctx context.Context) (string, error)
+	MissingIndices(ctx context.Context, n network.Network) ([]string, error)
+	BestBlock(ctx context.Context, n network.Network) (*explorer.Block, error)
+}
+
+type healthRepository struct {
+	elastic *elastic_cache.Index
+}
+
+func NewHealthRepository(elastic *elastic_cache.Index) HealthRepository {
+	return &healthRepository{elastic: elastic}
+}
+
+func (r *healthRepository) ClusterStatus(ctx context.Context) (string, error) {
+	health, err := r.elastic.Client.ClusterHealth().Do(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return health.Status, nil
+}
+
+func (r *healthRepository) MissingIndices(ctx context.Context, n network.Network) ([]string, error) {
+	missing := make([]string, 0)
+	for _, index := range elastic_cache.AllIndices {
+		exists, err := r.elastic.Client.IndexExists(index.Get(n)).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			missing = append(missing, index.Get(n))
+		}
+	}
+
+	return missing, nil
+}
+
+// BestBlock reads the indexed best block rather than the cached one, so readiness sees a stalled indexer
+func (r *healthRepository) BestBlock(ctx context.Context, n network.Network) (*explorer.Block, error) {
+	results, err := r.elastic.Client.Search().Index(elastic_cache.BlockIndex.Get(n)).
+		Sort("height", false).
+		Size(1).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Hits.Hits) == 0 {
+		return nil, ErrBlockNotFound
+	}
+
+	var block explorer.Block
+	if err := json.Unmarshal(results.Hits.Hits[0].Source, &block); err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
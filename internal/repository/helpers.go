@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
 	"github.com/olivere/elastic/v7"
 )
@@ -18,3 +19,115 @@ func sort(service *elastic.SearchService, sorter framework.Sort, defaultSort *de
 		service.Sort(defaultSort.Field, defaultSort.Ascending)
 	}
 }
+
+// paginate applies page/size pagination, or search_after when a cursor is requested.
+// The tiebreakers are appended to the sort so every hit has a unique cursor.
+func paginate(service *elastic.SearchService, p framework.Pagination, sorter framework.Sort, defaultSort *defaultSort, tiebreakers ...defaultSort) {
+	service.Size(p.Size())
+
+	cursor := p.Cursor()
+	if cursor == nil {
+		sort(service, sorter, defaultSort)
+		service.From(p.From())
+		return
+	}
+
+	sorts := make([]elastic.Sorter, 0)
+	fields := make(map[string]bool)
+	for _, so := range sorter.Options() {
+		sorts = append(sorts, cursorSort(so.Field(), so.Direction().Value(), cursor.Reverse))
+		fields[so.Field()] = true
+	}
+	if sorter.IsEmpty() && defaultSort != nil {
+		sorts = append(sorts, cursorSort(defaultSort.Field, defaultSort.Ascending, cursor.Reverse))
+		fields[defaultSort.Field] = true
+	}
+	for _, tiebreaker := range tiebreakers {
+		if !fields[tiebreaker.Field] {
+			sorts = append(sorts, cursorSort(tiebreaker.Field, tiebreaker.Ascending, cursor.Reverse))
+		}
+	}
+
+	service.SortBy(sorts...)
+	if !cursor.IsStart() {
+		service.SearchAfter(cursor.Values...)
+	}
+}
+
+func cursorSort(field string, ascending bool, reverse bool) elastic.Sorter {
+	if reverse {
+		ascending = !ascending
+	}
+
+	return elastic.NewFieldSort(field).Order(ascending)
+}
+
+// cursors restores the requested order of a reversed search and sets the next and previous cursors from the hit sort values
+func cursors(results *elastic.SearchResult, p framework.Pagination) {
+	cursor := p.Cursor()
+	if cursor == nil || results.Hits == nil {
+		return
+	}
+
+	hits := results.Hits.Hits
+	if cursor.Reverse {
+		for i, j := 0, len(hits)-1; i < j; i, j = i+1, j-1 {
+			hits[i], hits[j] = hits[j], hits[i]
+		}
+	}
+
+	if len(hits) == 0 {
+		// Paging past either end keeps a cursor back to where the request started
+		if cursor.Reverse {
+			p.SetCursors(framework.EncodeCursor(framework.Cursor{Values: cursor.Values}), "")
+		} else if !cursor.IsStart() {
+			p.SetCursors("", framework.EncodeCursor(framework.Cursor{Values: cursor.Values, Reverse: true}))
+		}
+		return
+	}
+
+	full := len(hits) == p.Size()
+
+	var next, prev string
+	if !cursor.Reverse && full || cursor.Reverse {
+		next = framework.EncodeCursor(framework.Cursor{Values: hits[len(hits)-1].Sort})
+	}
+	if cursor.Reverse && full || !cursor.Reverse && !cursor.IsStart() {
+		prev = framework.EncodeCursor(framework.Cursor{Values: hits[0].Sort, Reverse: true})
+	}
+
+	p.SetCursors(next, prev)
+}
+
+// updatedSince calls back with every document of the index updated after the height, in batches ordered by update
+func updatedSince(client *elastic.Client, index string, height uint64, callback func(hit *elastic.SearchHit) error) error {
+	query := elastic.NewBoolQuery().Filter(elastic.NewRangeQuery("updatedOnBlock").Gt(height))
+
+	var searchAfter []interface{}
+	for {
+		service := client.Search(index).
+			Query(query).
+			Sort("updatedOnBlock", true).
+			Sort("hash.keyword", true).
+			Size(exportBatchSize)
+		if searchAfter != nil {
+			service.SearchAfter(searchAfter...)
+		}
+
+		results, err := service.Do(context.Background())
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range results.Hits.Hits {
+			if err := callback(hit); err != nil {
+				return err
+			}
+			searchAfter = hit.Sort
+		}
+
+		if len(results.Hits.Hits) < exportBatchSize {
+			return nil
+		}
+	}
+}
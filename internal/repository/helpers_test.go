@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/olivere/elastic/v7"
+)
+
+// searchBody returns the body of the search request built by the callback
+func searchBody(t *testing.T, build func(service *elastic.SearchService)) map[string]interface{} {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("invalid search body %s", data)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"hits":{"hits":[]}}`))
+	}))
+	defer server.Close()
+
+	client, err := elastic.NewClient(elastic.SetURL(server.URL), elastic.SetSniff(false), elastic.SetHealthcheck(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := client.Search("block")
+	build(service)
+	if _, err := service.Do(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return body
+}
+
+func decodeJson(t *testing.T, value string) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded
+}
+
+func TestPaginate(t *testing.T) {
+	heightDesc := framework.NewSort(nil)
+	byHeight := &defaultSort{Field: "height", Ascending: false}
+	tiebreaker := defaultSort{Field: "hash.keyword", Ascending: true}
+
+	tests := []struct {
+		name       string
+		pagination framework.Pagination
+		want       string
+	}{
+		{
+			name:       "page and size",
+			pagination: framework.NewPagination(3, 20),
+			want:       `{"from":40,"size":20,"sort":[{"height":{"order":"desc"}}]}`,
+		},
+		{
+			name:       "first page of a cursor",
+			pagination: framework.NewCursorPagination(20, &framework.Cursor{}),
+			want:       `{"size":20,"sort":[{"height":{"order":"desc"}},{"hash.keyword":{"order":"asc"}}]}`,
+		},
+		{
+			name:       "next page of a cursor",
+			pagination: framework.NewCursorPagination(20, &framework.Cursor{Values: []interface{}{100, "abc"}}),
+			want:       `{"search_after":[100,"abc"],"size":20,"sort":[{"height":{"order":"desc"}},{"hash.keyword":{"order":"asc"}}]}`,
+		},
+		{
+			name:       "reverse cursor searches in the opposite direction",
+			pagination: framework.NewCursorPagination(20, &framework.Cursor{Values: []interface{}{100, "abc"}, Reverse: true}),
+			want:       `{"search_after":[100,"abc"],"size":20,"sort":[{"height":{"order":"asc"}},{"hash.keyword":{"order":"desc"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := searchBody(t, func(service *elastic.SearchService) {
+				paginate(service, tt.pagination, heightDesc, byHeight, tiebreaker)
+			})
+
+			if want := decodeJson(t, tt.want); !reflect.DeepEqual(body, want) {
+				t.Errorf("body = %v, want %v", body, want)
+			}
+		})
+	}
+}
+
+func TestPaginateDoesNotRepeatASortedTiebreaker(t *testing.T) {
+	option := framework.NewSort([]framework.SortOption{framework.NewSortOption("hash.keyword", framework.NewSortDirection("asc", true))})
+	body := searchBody(t, func(service *elastic.SearchService) {
+		paginate(service, framework.NewCursorPagination(5, &framework.Cursor{}), option, nil, defaultSort{Field: "hash.keyword", Ascending: true})
+	})
+
+	want := decodeJson(t, `{"size":5,"sort":[{"hash.keyword":{"order":"asc"}}]}`)
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("body = %v, want %v", body, want)
+	}
+}
+
+func TestCursors(t *testing.T) {
+	hits := func(heights ...int) *elastic.SearchResult {
+		result := &elastic.SearchResult{Hits: &elastic.SearchHits{}}
+		for _, height := range heights {
+			result.Hits.Hits = append(result.Hits.Hits, &elastic.SearchHit{Sort: []interface{}{height}})
+		}
+		return result
+	}
+	cursor := func(height int, reverse bool) string {
+		return framework.EncodeCursor(framework.Cursor{Values: []interface{}{height}, Reverse: reverse})
+	}
+
+	tests := []struct {
+		name   string
+		cursor *framework.Cursor
+		result *elastic.SearchResult
+		order  []int
+		next   string
+		prev   string
+	}{
+		{
+			name:   "first full page",
+			cursor: &framework.Cursor{},
+			result: hits(10, 9),
+			order:  []int{10, 9},
+			next:   cursor(9, false),
+		},
+		{
+			name:   "middle page",
+			cursor: &framework.Cursor{Values: []interface{}{11}},
+			result: hits(10, 9),
+			order:  []int{10, 9},
+			next:   cursor(9, false),
+			prev:   cursor(10, true),
+		},
+		{
+			name:   "last page",
+			cursor: &framework.Cursor{Values: []interface{}{11}},
+			result: hits(10),
+			order:  []int{10},
+			prev:   cursor(10, true),
+		},
+		{
+			name:   "reverse page is restored to the requested order",
+			cursor: &framework.Cursor{Values: []interface{}{8}, Reverse: true},
+			result: hits(9, 10),
+			order:  []int{10, 9},
+			next:   cursor(9, false),
+			prev:   cursor(10, true),
+		},
+		{
+			name:   "reverse page reaching the start",
+			cursor: &framework.Cursor{Values: []interface{}{9}, Reverse: true},
+			result: hits(10),
+			order:  []int{10},
+			next:   cursor(10, false),
+		},
+		{
+			name:   "paging past the end",
+			cursor: &framework.Cursor{Values: []interface{}{1}},
+			result: hits(),
+			order:  []int{},
+			prev:   cursor(1, true),
+		},
+		{
+			name:   "paging past the start",
+			cursor: &framework.Cursor{Values: []interface{}{10}, Reverse: true},
+			result: hits(),
+			order:  []int{},
+			next:   cursor(10, false),
+		},
+		{
+			name:   "empty first page",
+			cursor: &framework.Cursor{},
+			result: hits(),
+			order:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := framework.NewCursorPagination(2, tt.cursor)
+			cursors(tt.result, p)
+
+			order := make([]int, 0)
+			for _, hit := range tt.result.Hits.Hits {
+				order = append(order, hit.Sort[0].(int))
+			}
+			if !reflect.DeepEqual(order, tt.order) {
+				t.Errorf("order = %v, want %v", order, tt.order)
+			}
+			if p.Next() != tt.next {
+				t.Errorf("Next() = %q, want %q", p.Next(), tt.next)
+			}
+			if p.Prev() != tt.prev {
+				t.Errorf("Prev() = %q, want %q", p.Prev(), tt.prev)
+			}
+		})
+	}
+}
+
+func TestCursorsWithoutCursorPagination(t *testing.T) {
+	p := framework.NewPagination(1, 2)
+	cursors(&elastic.SearchResult{Hits: &elastic.SearchHits{Hits: []*elastic.SearchHit{{Sort: []interface{}{1}}}}}, p)
+
+	if p.Next() != "" || p.Prev() != "" {
+		t.Errorf("Next() = %q, Prev() = %q, want no cursors", p.Next(), p.Prev())
+	}
+}
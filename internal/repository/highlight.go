@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/olivere/elastic/v7"
+
+// highlight returns HTML escaped fragments of the fields with each match wrapped in <em> tags
+func highlight(fields ...string) *elastic.Highlight {
+	h := elastic.NewHighlight().
+		Encoder("html").
+		PreTags("<em>").
+		PostTags("</em>").
+		FragmentSize(150).
+		NumOfFragments(3)
+	for _, field := range fields {
+		h = h.Field(field)
+	}
+
+	return h
+}
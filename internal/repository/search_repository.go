@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/elastic_cache"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/search/entity"
+	"github.com/olivere/elastic/v7"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The shortest input matched against hash prefixes and text, shorter input would match too much
+const minSuggestLength = 3
+
+var (
+	hexPattern    = regexp.MustCompile("^[0-9a-fA-F]+$")
+	base58Pattern = regexp.MustCompile("^[1-9A-HJ-NP-Za-km-z]+$")
+)
+
+type SearchRepository interface {
+	Suggest(ctx context.Context, n network.Network, suggestionType entity.SuggestionType, query string, limit int) ([]*entity.Suggestion, error)
+}
+
+type searchRepository struct {
+	elastic *elastic_cache.Index
+}
+
+// suggester describes how each entity type is matched and read
+type suggester struct {
+	index     elastic_cache.Indices
+	hashField string
+	hexHash   bool
+	textField string
+	// textPath is the nested path of the text field, or "" when it is not nested
+	textPath string
+	height   bool
+	text     func(hit *suggestHit) string
+}
+
+type suggestHit struct {
+	Hash        string            `json:"hash"`
+	Height      uint64            `json:"height"`
+	Description string            `json:"description"`
+	Question    string            `json:"question"`
+	Meta        map[string]string `json:"meta"`
+}
+
+var suggesters = map[entity.SuggestionType]suggester{
+	entity.SuggestBlock: {
+		index: elastic_cache.BlockIndex, hashField: "hash", hexHash: true, height: true,
+	},
+	entity.SuggestTransaction: {
+		index: elastic_cache.BlockTransactionIndex, hashField: "hash", hexHash: true,
+	},
+	entity.SuggestAddress: {
+		index: elastic_cache.AddressIndex, hashField: "hash.keyword", textField: "meta.label", textPath: "meta",
+		text: func(hit *suggestHit) string { return hit.Meta["label"] },
+	},
+	entity.SuggestProposal: {
+		index: elastic_cache.ProposalIndex, hashField: "hash.keyword", hexHash: true, textField: "description",
+		text: func(hit *suggestHit) string { return hit.Description },
+	},
+	entity.SuggestPaymentRequest: {
+		index: elastic_cache.PaymentRequestIndex, hashField: "hash.keyword", hexHash: true, textField: "description",
+		text: func(hit *suggestHit) string { return hit.Description },
+	},
+	entity.SuggestConsultation: {
+		index: elastic_cache.DaoConsultationIndex, hashField: "hash.keyword", hexHash: true, textField: "question",
+		text: func(hit *suggestHit) string { return hit.Question },
+	},
+}
+
+func NewSearchRepository(elastic *elastic_cache.Index) SearchRepository {
+	return &searchRepository{elastic: elastic}
+}
+
+// Suggest finds entities of the type whose hash is or starts with the query, whose height is the query
+// or whose text matches the query. Each hit is named by the best way it matched.
+func (r *searchRepository) Suggest(ctx context.Context, n network.Network, suggestionType entity.SuggestionType, query string, limit int) ([]*entity.Suggestion, error) {
+	s, ok := suggesters[suggestionType]
+	if !ok {
+		return make([]*entity.Suggestion, 0), nil
+	}
+
+	clauses := s.clauses(strings.TrimSpace(query))
+	if len(clauses) == 0 {
+		return make([]*entity.Suggestion, 0), nil
+	}
+
+	results, err := r.elastic.Client.Search(s.index.Get(n)).
+		Query(elastic.NewBoolQuery().Should(clauses...).MinimumNumberShouldMatch(1)).
+		FetchSourceContext(elastic.NewFetchSourceContext(true).Include("hash", "height", "description", "question", "meta.label")).
+		Size(limit).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]*entity.Suggestion, 0)
+	for _, hit := range results.Hits.Hits {
+		var source *suggestHit
+		if err := json.Unmarshal(hit.Source, &source); err != nil {
+			continue
+		}
+
+		suggestion := &entity.Suggestion{
+			Type:  suggestionType,
+			Value: source.Hash,
+			Match: bestMatch(hit.MatchedQueries),
+		}
+		if hit.Score != nil {
+			suggestion.Score = *hit.Score
+		}
+		if s.height {
+			suggestion.Height = source.Height
+		}
+		if s.text != nil {
+			suggestion.Text = s.text(source)
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
+
+func (s suggester) clauses(query string) []elastic.Query {
+	clauses := make([]elastic.Query, 0)
+
+	if s.height {
+		if height, err := strconv.ParseUint(query, 10, 64); err == nil {
+			clauses = append(clauses, elastic.NewTermQuery("height", height).QueryName(string(entity.MatchExact)))
+		}
+	}
+
+	hash := query
+	if s.hexHash {
+		// Hex hashes are indexed in lower case
+		hash = strings.ToLower(query)
+	}
+	if (s.hexHash && hexPattern.MatchString(query)) || (!s.hexHash && base58Pattern.MatchString(query)) {
+		clauses = append(clauses, elastic.NewTermQuery(s.hashField, hash).QueryName(string(entity.MatchExact)))
+		if len(query) >= minSuggestLength {
+			clauses = append(clauses, elastic.NewPrefixQuery(s.hashField, hash).QueryName(string(entity.MatchPrefix)))
+		}
+	}
+
+	if s.textField != "" && len(query) >= minSuggestLength {
+		phrase := elastic.NewMatchPhrasePrefixQuery(s.textField, query)
+		fuzzy := elastic.NewMatchQuery(s.textField, query).Fuzziness("AUTO")
+
+		if s.textPath != "" {
+			// The names of queries within a nested query are not returned with the hit so the nested query is named
+			clauses = append(clauses,
+				elastic.NewNestedQuery(s.textPath, phrase).ScoreMode("max").QueryName(string(entity.MatchText)),
+				elastic.NewNestedQuery(s.textPath, fuzzy).ScoreMode("max").QueryName(string(entity.MatchText)),
+			)
+		} else {
+			clauses = append(clauses,
+				phrase.QueryName(string(entity.MatchText)),
+				fuzzy.QueryName(string(entity.MatchText)),
+			)
+		}
+	}
+
+	return clauses
+}
+
+func bestMatch(matched []string) entity.SuggestionMatch {
+	best := entity.MatchText
+	for _, name := range matched {
+		switch entity.SuggestionMatch(name) {
+		case entity.MatchExact:
+			return entity.MatchExact
+		case entity.MatchPrefix:
+			best = entity.MatchPrefix
+		}
+	}
+
+	return best
+}
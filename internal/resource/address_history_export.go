@@ -0,0 +1,135 @@
+package resource
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"io"
+	"strconv"
+	"time"
+)
+
+type historyExporter interface {
+	ContentType() string
+	Extension() string
+	Write(history *explorer.AddressHistory) error
+	Flush() error
+	// Fail ends a truncated export with a record marking the error
+	Fail(err error) error
+}
+
+func newHistoryExporter(format string, w io.Writer) historyExporter {
+	switch format {
+	case "csv":
+		return &csvHistoryExporter{writer: csv.NewWriter(w)}
+	case "ndjson":
+		return &ndjsonHistoryExporter{encoder: json.NewEncoder(w)}
+	}
+
+	return nil
+}
+
+var csvHistoryHeader = []string{
+	"height",
+	"txindex",
+	"time",
+	"txid",
+	"hash",
+	"changes.spendable",
+	"changes.stakable",
+	"changes.voting_weight",
+	"balance.spendable",
+	"balance.stakable",
+	"balance.voting_weight",
+	"is_stake",
+	"is_coldstake",
+	"is_cfund_payout",
+	"is_stake_payout",
+	"is_multisig",
+}
+
+type csvHistoryExporter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvHistoryExporter) ContentType() string {
+	return "text/csv"
+}
+
+func (e *csvHistoryExporter) Extension() string {
+	return "csv"
+}
+
+func (e *csvHistoryExporter) Write(history *explorer.AddressHistory) error {
+	if !e.headerWritten {
+		if err := e.writer.Write(csvHistoryHeader); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+
+	return e.writer.Write([]string{
+		strconv.FormatUint(history.Height, 10),
+		strconv.FormatUint(uint64(history.TxIndex), 10),
+		history.Time.UTC().Format(time.RFC3339),
+		history.TxId,
+		history.Hash,
+		strconv.FormatInt(history.Changes.Spendable, 10),
+		strconv.FormatInt(history.Changes.Stakable, 10),
+		strconv.FormatInt(history.Changes.VotingWeight, 10),
+		strconv.FormatInt(history.Balance.Spendable, 10),
+		strconv.FormatInt(history.Balance.Stakable, 10),
+		strconv.FormatInt(history.Balance.VotingWeight, 10),
+		strconv.FormatBool(history.Stake),
+		strconv.FormatBool(history.ColdStake),
+		strconv.FormatBool(history.CfundPayout),
+		strconv.FormatBool(history.StakePayout),
+		strconv.FormatBool(history.MultiSig),
+	})
+}
+
+func (e *csvHistoryExporter) Flush() error {
+	if !e.headerWritten {
+		if err := e.writer.Write(csvHistoryHeader); err != nil {
+			return err
+		}
+		e.headerWritten = true
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvHistoryExporter) Fail(err error) error {
+	if err := e.writer.Write([]string{"#error", err.Error()}); err != nil {
+		return err
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonHistoryExporter struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonHistoryExporter) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (e *ndjsonHistoryExporter) Extension() string {
+	return "ndjson"
+}
+
+func (e *ndjsonHistoryExporter) Write(history *explorer.AddressHistory) error {
+	return e.encoder.Encode(history)
+}
+
+func (e *ndjsonHistoryExporter) Flush() error {
+	return nil
+}
+
+func (e *ndjsonHistoryExporter) Fail(err error) error {
+	return e.encoder.Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,71 @@
+package resource
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"io"
+	"strings"
+)
+
+// The largest accepted import body
+const maxMetaImportBytes = 5 << 20
+
+var ErrMetaImportFormat = errors.New("Invalid format, use csv or json")
+
+var csvMetaImportHeader = []string{"address", "key", "value"}
+
+// parseAddressMetaImport reads JSON address meta changes, or CSV rows of address, key and value
+// where an empty value deletes the key. Rows for the same address are grouped in to one change.
+func parseAddressMetaImport(format string, r io.Reader) ([]*entity.AddressMetaChange, error) {
+	switch format {
+	case "json":
+		changes := make([]*entity.AddressMetaChange, 0)
+		if err := json.NewDecoder(r).Decode(&changes); err != nil {
+			return nil, fmt.Errorf("Invalid JSON: %s", err.Error())
+		}
+		return changes, nil
+	case "csv":
+		return parseCsvAddressMetaImport(r)
+	}
+
+	return nil, ErrMetaImportFormat
+}
+
+func parseCsvAddressMetaImport(r io.Reader) ([]*entity.AddressMetaChange, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvMetaImportHeader)
+	reader.TrimLeadingSpace = true
+
+	changes := make([]*entity.AddressMetaChange, 0)
+	byAddress := make(map[string]*entity.AddressMetaChange)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CSV: %s", err.Error())
+		}
+		if line == 1 && strings.EqualFold(strings.Join(record, ","), strings.Join(csvMetaImportHeader, ",")) {
+			continue
+		}
+
+		address, key, value := record[0], record[1], record[2]
+		change, ok := byAddress[address]
+		if !ok {
+			change = &entity.AddressMetaChange{Address: address, Meta: make(map[string]*string)}
+			byAddress[address] = change
+			changes = append(changes, change)
+		}
+
+		change.Meta[key] = nil
+		if value != "" {
+			change.Meta[key] = &value
+		}
+	}
+
+	return changes, nil
+}
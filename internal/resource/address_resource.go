@@ -4,16 +4,22 @@ import (
 	"errors"
 	"fmt"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/cache"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/framework/paginator"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/group"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 	"net/http"
 	"strconv"
 	"strings"
 )
 
+const exportStatusTrailer = "X-Export-Status"
+
 type AddressResource struct {
 	addressService address.Service
 	cache          *cache.Cache
@@ -81,6 +87,95 @@ func (r *AddressResource) GetHistory(c *gin.Context) {
 	c.JSON(200, history)
 }
 
+func (r *AddressResource) ExportHistory(c *gin.Context) {
+	req := rest(c)
+
+	exporter := newHistoryExporter(c.DefaultQuery("format", "csv"), c.Writer)
+	if exporter == nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid format `%s`", c.Query("format")))
+		return
+	}
+
+	hash := c.Param("hash")
+	c.Header("Content-Type", exporter.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s-history.%s\"", hash, exporter.Extension()))
+	c.Header("Trailer", exportStatusTrailer)
+
+	rows := 0
+	err := r.addressService.ExportHistory(req.Network(), hash, req, func(history *explorer.AddressHistory) error {
+		if err := exporter.Write(history); err != nil {
+			return err
+		}
+		rows++
+		if rows%1000 == 0 {
+			if err := exporter.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+
+		return nil
+	})
+	if err == nil {
+		err = exporter.Flush()
+	}
+
+	if err != nil {
+		zap.L().With(zap.Error(err), zap.String("hash", hash), zap.Int("rows", rows)).Error("Address: Failed to export history")
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Trailer")
+			errorInternalServerError(c, err.Error())
+			return
+		}
+
+		// The status is already sent, so the truncation is marked in the body and the trailer
+		if failErr := exporter.Fail(err); failErr != nil {
+			zap.L().With(zap.Error(failErr), zap.String("hash", hash)).Error("Address: Failed to mark the export as truncated")
+		}
+		c.Writer.Header().Set(exportStatusTrailer, "error")
+		return
+	}
+
+	c.Writer.Header().Set(exportStatusTrailer, "complete")
+}
+
+func (r *AddressResource) GetBalance(c *gin.Context) {
+	var snapshot *entity.BalanceSnapshot
+	var err error
+
+	if heightParam, exists := c.GetQuery("height"); exists {
+		height, parseErr := strconv.ParseUint(heightParam, 10, 64)
+		if parseErr != nil {
+			ErrorBadRequest(c, fmt.Sprintf("Invalid height `%s`", heightParam))
+			return
+		}
+		snapshot, err = r.addressService.GetBalanceAtHeight(network(c), c.Param("hash"), height)
+	} else if timeParam, exists := c.GetQuery("time"); exists {
+		t, parseErr := parseTime(timeParam)
+		if parseErr != nil {
+			ErrorBadRequest(c, fmt.Sprintf("Invalid time `%s`", timeParam))
+			return
+		}
+		snapshot, err = r.addressService.GetBalanceAtTime(network(c), c.Param("hash"), t)
+	} else {
+		ErrorBadRequest(c, "Either height or time is required")
+		return
+	}
+
+	if err != nil {
+		if err == repository.ErrAddressHistoryNotFound {
+			errorNotFound(c, err.Error())
+		} else {
+			errorInternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	c.JSON(200, snapshot)
+}
+
 func (r *AddressResource) ValidateAddress(c *gin.Context) {
 	validateAddress, err := r.addressService.ValidateAddress(network(c), c.Param("hash"))
 	if err != nil {
@@ -150,6 +245,36 @@ func (r *AddressResource) GetStakingChart(c *gin.Context) {
 	c.JSON(200, chart)
 }
 
+func (r *AddressResource) GetStakingReport(c *gin.Context) {
+	var heights [2]uint64
+	// Heights are from_height and to_height, as from and to are times on /staking/leaderboard
+	for i, param := range []string{"from_height", "to_height"} {
+		if value := c.Query(param); value != "" {
+			height, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				ErrorBadRequest(c, fmt.Sprintf("Invalid %s `%s`", param, value))
+				return
+			}
+			heights[i] = height
+		}
+	}
+
+	report, err := r.addressService.GetStakingPerformance(network(c), c.Param("hash"), heights[0], heights[1])
+	if err != nil {
+		switch {
+		case err == repository.ErrAddressHistoryNotFound:
+			errorNotFound(c, err.Error())
+		case address.IsStakingReportError(err):
+			ErrorBadRequest(c, err.Error())
+		default:
+			errorInternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	c.JSON(200, report)
+}
+
 func (r *AddressResource) GetAssociatedStakingAddresses(c *gin.Context) {
 	addresses, err := r.addressService.GetAssociatedStakingAddresses(network(c), c.Param("hash"))
 	if err != nil {
@@ -192,11 +317,105 @@ func (r *AddressResource) PutAddressMeta(c *gin.Context) {
 		return
 	}
 
-	err := r.addressService.PutAddressMeta(network(c), c.Param("hash"), key, value)
+	err := r.addressService.PutAddressMeta(network(c), c.Param("hash"), key, value, auditTrail(c))
 	if err != nil {
-		handleError(c, err, http.StatusInternalServerError)
+		handleAddressMetaError(c, err)
 		return
 	}
 
 	c.JSON(200, nil)
 }
+
+func (r *AddressResource) GetAddressMeta(c *gin.Context) {
+	a, err := r.addressService.GetAddress(network(c), c.Param("hash"))
+	if err != nil {
+		handleAddressMetaError(c, err)
+		return
+	}
+
+	meta := a.Meta
+	if meta == nil {
+		meta = map[string]string{}
+	}
+
+	c.JSON(200, meta)
+}
+
+// UpdateAddressMeta merges a JSON object of meta into the address meta, a null value deletes the key
+func (r *AddressResource) UpdateAddressMeta(c *gin.Context) {
+	meta := make(map[string]*string)
+	if err := c.ShouldBindJSON(&meta); err != nil {
+		ErrorBadRequest(c, framework.ErrorInvalidJson.Error())
+		return
+	}
+
+	updated, err := r.addressService.UpdateAddressMeta(network(c), c.Param("hash"), meta, auditTrail(c))
+	if err != nil {
+		handleAddressMetaError(c, err)
+		return
+	}
+
+	c.JSON(200, updated)
+}
+
+func (r *AddressResource) DeleteAddressMeta(c *gin.Context) {
+	meta := map[string]*string{c.Param("key"): nil}
+
+	updated, err := r.addressService.UpdateAddressMeta(network(c), c.Param("hash"), meta, auditTrail(c))
+	if err != nil {
+		handleAddressMetaError(c, err)
+		return
+	}
+
+	c.JSON(200, updated)
+}
+
+func (r *AddressResource) ImportAddressMeta(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxMetaImportBytes)
+
+	changes, err := parseAddressMetaImport(c.DefaultQuery("format", "json"), c.Request.Body)
+	if err != nil {
+		ErrorBadRequest(c, err.Error())
+		return
+	}
+
+	result, err := r.addressService.ImportAddressMeta(network(c), changes, auditTrail(c))
+	if err != nil {
+		handleAddressMetaError(c, err)
+		return
+	}
+
+	c.JSON(200, result)
+}
+
+// GetMetaAddresses lists the labelled addresses, or the addresses with another meta key, by balance
+func (r *AddressResource) GetMetaAddresses(c *gin.Context) {
+	key := c.DefaultQuery("key", entity.LabelKey)
+	if key == "*" {
+		key = ""
+	}
+
+	addresses, total, err := r.addressService.GetMetaAddresses(network(c), key, pagination(c))
+	if err != nil {
+		handleAddressMetaError(c, err)
+		return
+	}
+
+	paginator := paginator.NewPaginator(len(addresses), total, pagination(c))
+	paginator.WriteHeader(c)
+
+	c.JSON(200, addresses)
+}
+
+func handleAddressMetaError(c *gin.Context, err error) {
+	switch {
+	case err == repository.ErrAddressNotFound:
+		errorNotFound(c, err.Error())
+	case err == repository.ErrAddressMetaConflict:
+		handleError(c, err, http.StatusConflict)
+	case address.IsAddressMetaError(err):
+		ErrorBadRequest(c, err.Error())
+	default:
+		errorInternalServerError(c, err.Error())
+	}
+}
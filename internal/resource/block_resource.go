@@ -5,22 +5,28 @@ import (
 	"github.com/navcoin/navexplorer-api-go/v2/internal/cache"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/framework/paginator"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	blockEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/block/entity"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/group"
+	networkService "github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"go.uber.org/zap"
 	"strconv"
 )
 
 type BlockResource struct {
-	blockService block.Service
-	daoService   dao.Service
-	cache        *cache.Cache
+	blockService   block.Service
+	daoService     dao.Service
+	addressService address.Service
+	cache          *cache.Cache
 }
 
-func NewBlockResource(blockService block.Service, daoService dao.Service, cache *cache.Cache) *BlockResource {
-	return &BlockResource{blockService, daoService, cache}
+func NewBlockResource(blockService block.Service, daoService dao.Service, addressService address.Service, cache *cache.Cache) *BlockResource {
+	return &BlockResource{blockService, daoService, addressService, cache}
 }
 
 func (r *BlockResource) GetBestBlock(c *gin.Context) {
@@ -140,7 +146,7 @@ func (r *BlockResource) GetTransactionsByBlock(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, tx)
+	c.JSON(200, r.labelTransactions(network(c), tx))
 }
 
 func (r *BlockResource) GetTransactionByHash(c *gin.Context) {
@@ -154,7 +160,7 @@ func (r *BlockResource) GetTransactionByHash(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, tx)
+	c.JSON(200, r.labelTransactions(network(c), []*explorer.BlockTransaction{tx})[0])
 }
 
 func (r *BlockResource) GetRawTransactionByHash(c *gin.Context) {
@@ -195,5 +201,35 @@ func (r *BlockResource) GetTransactions(c *gin.Context) {
 	paginate := paginator.NewPaginator(len(txs), total, req.Pagination())
 	paginate.WriteHeader(c)
 
-	c.JSON(200, txs)
+	c.JSON(200, r.labelTransactions(req.Network(), txs))
+}
+
+// labelTransactions adds the labels of the input and output addresses, the transactions are returned unlabelled when the labels fail to load
+func (r *BlockResource) labelTransactions(n networkService.Network, txs []*explorer.BlockTransaction) []*blockEntity.LabelledTransaction {
+	addresses := make([]string, 0)
+	for _, tx := range txs {
+		addresses = append(addresses, tx.GetAllAddresses()...)
+	}
+
+	labels, err := r.addressService.GetLabels(n, addresses)
+	if err != nil {
+		zap.L().With(zap.Error(err)).Error("Failed to get address labels")
+		labels = make(map[string]string)
+	}
+
+	labelled := make([]*blockEntity.LabelledTransaction, 0, len(txs))
+	for _, tx := range txs {
+		labelledTx := &blockEntity.LabelledTransaction{BlockTransaction: tx}
+		for _, a := range tx.GetAllAddresses() {
+			if label, ok := labels[a]; ok {
+				if labelledTx.Labels == nil {
+					labelledTx.Labels = make(map[string]string)
+				}
+				labelledTx.Labels[a] = label
+			}
+		}
+		labelled = append(labelled, labelledTx)
+	}
+
+	return labelled
 }
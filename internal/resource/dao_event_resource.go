@@ -0,0 +1,39 @@
+package resource
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework/paginator"
+	daoEvent "github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/event"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type DaoEventResource struct {
+	daoEventService daoEvent.Service
+}
+
+func NewDaoEventResource(daoEventService daoEvent.Service) *DaoEventResource {
+	return &DaoEventResource{daoEventService}
+}
+
+func (r *DaoEventResource) GetEvents(c *gin.Context) {
+	var parameters daoEvent.Parameters
+	if err := c.BindQuery(&parameters); err != nil {
+		ErrorBadRequest(c, "Invalid request")
+		return
+	}
+
+	events, total, err := r.daoEventService.GetEvents(network(c), parameters, pagination(c))
+	if err != nil {
+		if daoEvent.IsDaoEventError(err) {
+			handleError(c, err, http.StatusBadRequest)
+		} else {
+			errorInternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	paginate := paginator.NewPaginator(len(events), total, pagination(c))
+	paginate.WriteHeader(c)
+
+	c.JSON(200, events)
+}
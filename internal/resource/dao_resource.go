@@ -94,6 +94,11 @@ func (r *DaoResource) GetProposals(c *gin.Context) {
 		return
 	}
 
+	if parameters.Query != "" {
+		r.searchProposals(c, parameters)
+		return
+	}
+
 	proposals, total, err := r.daoService.GetProposals(network(c), parameters, pagination(c))
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": err, "status": http.StatusInternalServerError})
@@ -106,6 +111,19 @@ func (r *DaoResource) GetProposals(c *gin.Context) {
 	c.JSON(200, proposals)
 }
 
+func (r *DaoResource) searchProposals(c *gin.Context, parameters dao.ProposalParameters) {
+	proposals, total, err := r.daoService.SearchProposals(network(c), parameters, pagination(c))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": err, "status": http.StatusInternalServerError})
+		return
+	}
+
+	paginate := paginator.NewPaginator(len(proposals), total, pagination(c))
+	paginate.WriteHeader(c)
+
+	c.JSON(200, proposals)
+}
+
 func (r *DaoResource) GetProposal(c *gin.Context) {
 	proposal, err := r.daoService.GetProposal(network(c), c.Param("hash"))
 
@@ -242,6 +260,11 @@ func (r *DaoResource) GetConsultations(c *gin.Context) {
 		return
 	}
 
+	if parameters.Query != "" {
+		r.searchConsultations(c, parameters)
+		return
+	}
+
 	consultations, total, err := r.daoService.GetConsultations(network(c), parameters, pagination(c))
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": err, "status": http.StatusInternalServerError})
@@ -254,6 +277,19 @@ func (r *DaoResource) GetConsultations(c *gin.Context) {
 	c.JSON(200, consultations)
 }
 
+func (r *DaoResource) searchConsultations(c *gin.Context, parameters dao.ConsultationParameters) {
+	consultations, total, err := r.daoService.SearchConsultations(network(c), parameters, pagination(c))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": err, "status": http.StatusInternalServerError})
+		return
+	}
+
+	paginate := paginator.NewPaginator(len(consultations), total, pagination(c))
+	paginate.WriteHeader(c)
+
+	c.JSON(200, consultations)
+}
+
 func (r *DaoResource) GetConsultation(c *gin.Context) {
 	proposal, err := r.daoService.GetConsultation(network(c), c.Param("hash"))
 
@@ -0,0 +1,243 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/esplora"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+type EsploraResource struct {
+	esploraService esplora.Service
+}
+
+func NewEsploraResource(esploraService esplora.Service) *EsploraResource {
+	return &EsploraResource{esploraService}
+}
+
+// GetTipHeight, GetTipHash, GetBlockHash, GetBlockTxid and GetTransactionHex respond with plain text
+func (r *EsploraResource) GetTipHeight(c *gin.Context) {
+	height, err := r.esploraService.GetTipHeight(network(c))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.String(200, "%d", height)
+}
+
+func (r *EsploraResource) GetTipHash(c *gin.Context) {
+	hash, err := r.esploraService.GetTipHash(network(c))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.String(200, hash)
+}
+
+func (r *EsploraResource) GetBlockHash(c *gin.Context) {
+	height, err := strconv.ParseUint(c.Param("height"), 10, 64)
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid height `%s`", c.Param("height")))
+		return
+	}
+
+	hash, err := r.esploraService.GetBlockHash(network(c), height)
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.String(200, hash)
+}
+
+func (r *EsploraResource) GetBlocks(c *gin.Context) {
+	var startHeight *uint64
+	if c.Param("start_height") != "" {
+		height, err := strconv.ParseUint(c.Param("start_height"), 10, 64)
+		if err != nil {
+			ErrorBadRequest(c, fmt.Sprintf("Invalid start height `%s`", c.Param("start_height")))
+			return
+		}
+		startHeight = &height
+	}
+
+	blocks, err := r.esploraService.GetBlocks(network(c), startHeight)
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, blocks)
+}
+
+func (r *EsploraResource) GetBlock(c *gin.Context) {
+	b, err := r.esploraService.GetBlock(network(c), c.Param("hash"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, b)
+}
+
+func (r *EsploraResource) GetBlockStatus(c *gin.Context) {
+	status, err := r.esploraService.GetBlockStatus(network(c), c.Param("hash"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, status)
+}
+
+func (r *EsploraResource) GetBlockTxids(c *gin.Context) {
+	txids, err := r.esploraService.GetBlockTxids(network(c), c.Param("hash"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, txids)
+}
+
+func (r *EsploraResource) GetBlockTxid(c *gin.Context) {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid index `%s`", c.Param("index")))
+		return
+	}
+
+	txid, err := r.esploraService.GetBlockTxid(network(c), c.Param("hash"), index)
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.String(200, txid)
+}
+
+func (r *EsploraResource) GetBlockTransactions(c *gin.Context) {
+	startIndex := 0
+	if c.Param("start_index") != "" {
+		index, err := strconv.Atoi(c.Param("start_index"))
+		if err != nil {
+			ErrorBadRequest(c, fmt.Sprintf("Invalid start index `%s`", c.Param("start_index")))
+			return
+		}
+		startIndex = index
+	}
+
+	txs, err := r.esploraService.GetBlockTransactions(network(c), c.Param("hash"), startIndex)
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, txs)
+}
+
+func (r *EsploraResource) GetTransaction(c *gin.Context) {
+	tx, err := r.esploraService.GetTransaction(network(c), c.Param("txid"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, tx)
+}
+
+func (r *EsploraResource) GetTransactionStatus(c *gin.Context) {
+	status, err := r.esploraService.GetTransactionStatus(network(c), c.Param("txid"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, status)
+}
+
+func (r *EsploraResource) GetTransactionHex(c *gin.Context) {
+	hex, err := r.esploraService.GetTransactionHex(network(c), c.Param("txid"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.String(200, hex)
+}
+
+func (r *EsploraResource) GetOutspends(c *gin.Context) {
+	outspends, err := r.esploraService.GetOutspends(network(c), c.Param("txid"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, outspends)
+}
+
+func (r *EsploraResource) GetOutspend(c *gin.Context) {
+	vout, err := strconv.Atoi(c.Param("vout"))
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid vout `%s`", c.Param("vout")))
+		return
+	}
+
+	outspend, err := r.esploraService.GetOutspend(network(c), c.Param("txid"), vout)
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, outspend)
+}
+
+func (r *EsploraResource) GetAddress(c *gin.Context) {
+	a, err := r.esploraService.GetAddress(network(c), c.Param("addr"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, a)
+}
+
+// GetAddressTransactions pages through the confirmed transactions using the txid last seen on the previous page
+func (r *EsploraResource) GetAddressTransactions(c *gin.Context) {
+	txs, err := r.esploraService.GetAddressTransactions(network(c), c.Param("addr"), c.Param("last_seen"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, txs)
+}
+
+// GetAddressMempoolTransactions is always empty as only indexed blocks are known
+func (r *EsploraResource) GetAddressMempoolTransactions(c *gin.Context) {
+	c.JSON(200, []interface{}{})
+}
+
+func (r *EsploraResource) GetUnspentOutputs(c *gin.Context) {
+	utxos, err := r.esploraService.GetUnspentOutputs(network(c), c.Param("addr"))
+	if err != nil {
+		handleEsploraError(c, err)
+		return
+	}
+
+	c.JSON(200, utxos)
+}
+
+func handleEsploraError(c *gin.Context, err error) {
+	switch {
+	case err == repository.ErrBlockNotFound || err == esplora.ErrTransactionNotFound || err == esplora.ErrOutputNotFound || err == esplora.ErrRawTransactionUnavailable:
+		errorNotFound(c, err.Error())
+	case esplora.IsEsploraError(err):
+		ErrorBadRequest(c, err.Error())
+	default:
+		errorInternalServerError(c, err.Error())
+	}
+}
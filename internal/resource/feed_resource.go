@@ -0,0 +1,119 @@
+package resource
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/feed"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/feed/entity"
+	networkService "github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type FeedResource struct {
+	feedService feed.Service
+}
+
+func NewFeedResource(feedService feed.Service) *FeedResource {
+	return &FeedResource{feedService}
+}
+
+func (r *FeedResource) GetBlocks(c *gin.Context) {
+	r.write(c, r.feedService.GetBlocks)
+}
+
+func (r *FeedResource) GetProposals(c *gin.Context) {
+	r.write(c, r.feedService.GetProposals)
+}
+
+func (r *FeedResource) GetPaymentRequests(c *gin.Context) {
+	r.write(c, r.feedService.GetPaymentRequests)
+}
+
+func (r *FeedResource) GetConsultations(c *gin.Context) {
+	r.write(c, r.feedService.GetConsultations)
+}
+
+// write renders the feed in the requested format, or responds 304 when the client's copy is current
+func (r *FeedResource) write(c *gin.Context, get func(n networkService.Network, limit int) (*entity.Feed, error)) {
+	// The request's Host and forwarded headers are set by the client, so links are only made absolute with the base url
+	base := config.Get().Feed.BaseUrl
+	if base == "" {
+		errorServiceUnavailable(c, "Feeds are not available, FEED_BASE_URL is not set")
+		return
+	}
+
+	n := network(c)
+
+	// Feed readers cannot set headers so the network may also be given as a query parameter
+	if name := c.Query("network"); name != "" {
+		queryNetwork, err := networkService.GetNetwork(name)
+		if err != nil {
+			errorNetworkNotAvailable(c)
+			return
+		}
+		n = queryNetwork
+	}
+
+	format := c.DefaultQuery("format", string(feed.FormatAtom))
+	if !feed.IsFormatValid(format) {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid format `%s`, must be atom or rss", format))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(feed.DefaultFeedLimit)))
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid limit `%s`", c.Query("limit")))
+		return
+	}
+
+	f, err := get(n, limit)
+	if err != nil {
+		if feed.IsFeedError(err) {
+			ErrorBadRequest(c, err.Error())
+		} else {
+			errorInternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	body, err := feed.Encode(f, feed.Format(format), base, base+c.Request.URL.RequestURI())
+	if err != nil {
+		errorInternalServerError(c, err.Error())
+		return
+	}
+
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", f.Updated.UTC().Format(http.TimeFormat))
+	if notModified(c.Request, etag, f.Updated) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, feed.Format(format).ContentType(), body)
+}
+
+// notModified checks If-None-Match, or If-Modified-Since when no entity tags are given
+func notModified(request *http.Request, etag string, updated time.Time) bool {
+	if match := request.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !updated.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,60 @@
+package resource
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/graph"
+	"net/http"
+)
+
+type GraphQLResource struct {
+	schema graphql.Schema
+}
+
+func NewGraphQLResource(schema graphql.Schema) *GraphQLResource {
+	return &GraphQLResource{schema}
+}
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func (r *GraphQLResource) Query(c *gin.Context) {
+	var request GraphQLRequest
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				ErrorBadRequest(c, "Invalid variables")
+				return
+			}
+		}
+	} else if err := c.ShouldBindJSON(&request); err != nil {
+		ErrorBadRequest(c, "Invalid GraphQL request")
+		return
+	}
+
+	if request.Query == "" {
+		ErrorBadRequest(c, "Missing GraphQL query")
+		return
+	}
+
+	if err := graph.CheckLimits(r.schema, request.Query, request.OperationName, request.Variables); err != nil {
+		ErrorBadRequest(c, err.Error())
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         r.schema,
+		RequestString:  request.Query,
+		VariableValues: request.Variables,
+		OperationName:  request.OperationName,
+		Context:        graph.WithNetwork(c.Request.Context(), network(c)),
+	})
+
+	c.JSON(200, result)
+}
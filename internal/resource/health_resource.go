@@ -0,0 +1,33 @@
+package resource
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/health"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/health/entity"
+	"net/http"
+)
+
+type HealthResource struct {
+	healthService health.Service
+}
+
+func NewHealthResource(healthService health.Service) *HealthResource {
+	return &HealthResource{healthService}
+}
+
+func (r *HealthResource) GetLive(c *gin.Context) {
+	writeHealth(c, r.healthService.Live())
+}
+
+func (r *HealthResource) GetReady(c *gin.Context) {
+	writeHealth(c, r.healthService.Ready(c.Request.Context()))
+}
+
+func writeHealth(c *gin.Context, health *entity.Health) {
+	status := http.StatusOK
+	if health.Status != entity.StatusOk {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, health)
+}
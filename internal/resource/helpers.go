@@ -3,9 +3,13 @@ package resource
 import (
 	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/audit"
+	authEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/auth/entity"
 	networkService "github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 func rest(c *gin.Context) framework.RestRequest {
@@ -20,6 +24,14 @@ func pagination(c *gin.Context) framework.Pagination {
 	return rest(c).Pagination()
 }
 
+func credential(c *gin.Context) *authEntity.Credential {
+	return c.MustGet(framework.CREDENTIAL).(*authEntity.Credential)
+}
+
+func auditTrail(c *gin.Context) *audit.Trail {
+	return c.MustGet(framework.AUDIT_TRAIL).(*audit.Trail)
+}
+
 func networkHeader(c *gin.Context) string {
 	n := c.GetHeader("Network")
 	if n == "" {
@@ -50,6 +62,13 @@ func ErrorBadRequest(c *gin.Context, msg string) {
 	)
 }
 
+func errorServiceUnavailable(c *gin.Context, msg string) {
+	c.AbortWithStatusJSON(
+		http.StatusServiceUnavailable,
+		gin.H{"message": msg, "status": http.StatusServiceUnavailable},
+	)
+}
+
 func errorRequestError(c *gin.Context, err error) {
 	errorInternalServerError(c, "Failed to process request:"+err.Error())
 }
@@ -66,3 +85,22 @@ func handleError(c *gin.Context, err error, status int) {
 		"message": err.Error(),
 	})
 }
+
+// parseTime accepts an RFC3339 timestamp, a date or a unix timestamp
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		// A date covers the whole day
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(unix, 0).UTC(), nil
+}
@@ -0,0 +1,230 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/insight"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/insight/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type InsightResource struct {
+	insightService insight.Service
+}
+
+func NewInsightResource(insightService insight.Service) *InsightResource {
+	return &InsightResource{insightService}
+}
+
+func (r *InsightResource) GetStatus(c *gin.Context) {
+	status, err := r.insightService.GetStatus(network(c), c.Query("q"))
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, status)
+}
+
+func (r *InsightResource) GetSync(c *gin.Context) {
+	sync, err := r.insightService.GetSync(network(c))
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, sync)
+}
+
+func (r *InsightResource) GetBlock(c *gin.Context) {
+	b, err := r.insightService.GetBlock(network(c), c.Param("hash"))
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, b)
+}
+
+func (r *InsightResource) GetBlockIndex(c *gin.Context) {
+	height, err := strconv.ParseUint(c.Param("height"), 10, 64)
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid height `%s`", c.Param("height")))
+		return
+	}
+
+	index, err := r.insightService.GetBlockIndex(network(c), height)
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, index)
+}
+
+func (r *InsightResource) GetBlocks(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(insight.DefaultBlocksLimit)))
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid limit `%s`", c.Query("limit")))
+		return
+	}
+
+	blocks, err := r.insightService.GetBlocks(network(c), c.Query("blockDate"), limit)
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, blocks)
+}
+
+func (r *InsightResource) GetTransaction(c *gin.Context) {
+	tx, err := r.insightService.GetTransaction(network(c), c.Param("txid"))
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, tx)
+}
+
+func (r *InsightResource) GetRawTransaction(c *gin.Context) {
+	tx, err := r.insightService.GetRawTransaction(network(c), c.Param("txid"))
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, tx)
+}
+
+// GetTransactions lists the transactions of the block or address query parameter
+func (r *InsightResource) GetTransactions(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("pageNum", "0"))
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid page number `%s`", c.Query("pageNum")))
+		return
+	}
+
+	var block, addr = c.Query("block"), c.Query("address")
+	switch {
+	case block != "":
+		txs, err := r.insightService.GetBlockTransactions(network(c), block, page)
+		if err != nil {
+			handleInsightError(c, err)
+			return
+		}
+		c.JSON(200, txs)
+	case addr != "":
+		txs, err := r.insightService.GetAddressTransactions(network(c), addr, page)
+		if err != nil {
+			handleInsightError(c, err)
+			return
+		}
+		c.JSON(200, txs)
+	default:
+		handleInsightError(c, insight.ErrTransactionsQueryInvalid)
+	}
+}
+
+func (r *InsightResource) GetAddress(c *gin.Context) {
+	from, err := strconv.Atoi(c.DefaultQuery("from", "0"))
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid from `%s`", c.Query("from")))
+		return
+	}
+	to, err := strconv.Atoi(c.DefaultQuery("to", strconv.Itoa(from+insight.DefaultAddressTransactions)))
+	if err != nil {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid to `%s`", c.Query("to")))
+		return
+	}
+
+	a, err := r.insightService.GetAddress(network(c), c.Param("addr"), from, to, c.Query("noTxList") == "1")
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, a)
+}
+
+// GetBalance, GetTotalReceived, GetTotalSent and GetUnconfirmedBalance respond with a plain number of satoshi
+func (r *InsightResource) GetBalance(c *gin.Context) {
+	if a := r.getAddress(c); a != nil {
+		c.String(200, "%d", a.BalanceSat)
+	}
+}
+
+func (r *InsightResource) GetTotalReceived(c *gin.Context) {
+	if a := r.getAddress(c); a != nil {
+		c.String(200, "%d", a.TotalReceivedSat)
+	}
+}
+
+func (r *InsightResource) GetTotalSent(c *gin.Context) {
+	if a := r.getAddress(c); a != nil {
+		c.String(200, "%d", a.TotalSentSat)
+	}
+}
+
+func (r *InsightResource) GetUnconfirmedBalance(c *gin.Context) {
+	if a := r.getAddress(c); a != nil {
+		c.String(200, "%d", a.UnconfirmedBalanceSat)
+	}
+}
+
+func (r *InsightResource) GetUnspentOutputs(c *gin.Context) {
+	r.unspentOutputs(c, []string{c.Param("addr")})
+}
+
+// GetUnspentOutputsForAddresses takes a comma separated list of addresses from the path, or from the addrs form field when posted
+func (r *InsightResource) GetUnspentOutputsForAddresses(c *gin.Context) {
+	addrs := c.Param("addrs")
+	if c.Request.Method == http.MethodPost {
+		addrs = c.PostForm("addrs")
+	}
+
+	hashes := make([]string, 0)
+	for _, hash := range strings.Split(addrs, ",") {
+		if hash = strings.TrimSpace(hash); hash != "" {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	r.unspentOutputs(c, hashes)
+}
+
+func (r *InsightResource) unspentOutputs(c *gin.Context, hashes []string) {
+	utxos, err := r.insightService.GetUnspentOutputs(network(c), hashes)
+	if err != nil {
+		handleInsightError(c, err)
+		return
+	}
+
+	c.JSON(200, utxos)
+}
+
+func (r *InsightResource) getAddress(c *gin.Context) *entity.Address {
+	a, err := r.insightService.GetAddress(network(c), c.Param("addr"), 0, 0, true)
+	if err != nil {
+		handleInsightError(c, err)
+		return nil
+	}
+
+	return a
+}
+
+func handleInsightError(c *gin.Context, err error) {
+	switch {
+	case err == repository.ErrBlockNotFound || err == insight.ErrTransactionNotFound || err == insight.ErrRawTransactionUnavailable:
+		errorNotFound(c, err.Error())
+	case insight.IsInsightError(err) || address.IsUnspentError(err):
+		ErrorBadRequest(c, err.Error())
+	default:
+		errorInternalServerError(c, err.Error())
+	}
+}
@@ -0,0 +1,91 @@
+package resource
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework/paginator"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/openapi"
+	networkService "github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+)
+
+type OpenApiResource struct {
+	document *openapi.Document
+}
+
+func NewOpenApiResource() *OpenApiResource {
+	return &OpenApiResource{}
+}
+
+// Generate builds the specification from the registered routes.
+// A route which is not in openApiRoutes is left out of the specification and returned in the error.
+func (r *OpenApiResource) Generate(routes gin.RoutesInfo) error {
+	document := openapi.NewDocument(openapi.Info{
+		Title:       "NavExplorer API",
+		Description: "REST API for Navexplorer.com",
+		Version:     "2",
+	})
+
+	networks := make([]interface{}, 0)
+	for _, n := range networkService.GetNetworks() {
+		networks = append(networks, n.Name)
+	}
+
+	document.Components.Parameters["Network"] = &openapi.Parameter{
+		Name:        "Network",
+		In:          "header",
+		Description: "The network to query, or the network query parameter, the default network is used when both are omitted",
+		Schema:      &openapi.Schema{Type: "string", Enum: networks, Default: config.Get().DefaultNetwork},
+	}
+	document.Components.Parameters["page"] = queryParameter("page", "integer", "The page to return, starting at 1")
+	document.Components.Parameters["size"] = queryParameter("size", "integer", "The number of elements per page, at most 1000")
+	document.Components.Parameters["cursor"] = queryParameter("cursor", "string", "An opaque cursor from the X-Pagination header, empty for the first page")
+	document.Components.Parameters["sort"] = queryParameter("sort", "string", "Comma separated sort options, e.g. height:desc")
+	document.Components.Parameters["filters"] = queryParameter("filters", "string", "Comma separated filter expressions, e.g. height>=1000,fees>0")
+	document.Components.Parameters["period"] = queryParameter("period", "string", "The group period: hourly, daily, weekly or monthly")
+	document.Components.Parameters["count"] = queryParameter("count", "integer", "The number of groups to return")
+
+	document.Components.Headers["X-Pagination"] = &openapi.Header{
+		Description: "The pagination of the response",
+		Content: map[string]*openapi.MediaType{
+			"application/json": {Schema: document.Schema(paginator.Paginator{})},
+		},
+	}
+
+	document.Components.Responses["Error"] = &openapi.Response{
+		Description: "Error",
+		Content: map[string]*openapi.MediaType{
+			"application/json": {Schema: document.Schema(Error{})},
+		},
+	}
+
+	document.Components.SecuritySchemes["apiKey"] = &openapi.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}
+
+	specs := openApiRoutes()
+	for i := range specs {
+		specs[i].Parameters = append([]*openapi.Parameter{openapi.ParameterRef("Network")}, specs[i].Parameters...)
+	}
+
+	err := document.AddRoutes(specs, routes)
+	r.document = document
+
+	return err
+}
+
+func (r *OpenApiResource) GetSpecification(c *gin.Context) {
+	c.JSON(200, r.document)
+}
+
+// Error is the body of an error response
+type Error struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func queryParameter(name, schemaType, description string) *openapi.Parameter {
+	return &openapi.Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &openapi.Schema{Type: schemaType},
+	}
+}
@@ -0,0 +1,391 @@
+package resource
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/openapi"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	blockEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/block/entity"
+	daoEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/entity"
+	esploraEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/esplora/entity"
+	healthEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/health/entity"
+	insightEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/insight/entity"
+	searchEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/search/entity"
+	softforkEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/softfork/entity"
+	streamEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/stream/entity"
+	watchlistEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist/entity"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+)
+
+var paginated = map[string]*openapi.Header{"X-Pagination": openapi.HeaderRef("X-Pagination")}
+
+func parameters(names ...string) []*openapi.Parameter {
+	result := make([]*openapi.Parameter, 0)
+	for _, name := range names {
+		result = append(result, openapi.ParameterRef(name))
+	}
+
+	return result
+}
+
+func withParameters(refs []*openapi.Parameter, params ...*openapi.Parameter) []*openapi.Parameter {
+	return append(refs, params...)
+}
+
+func feedParameters() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		{Name: "format", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"atom", "rss"}, Default: "atom"}},
+		queryParameter("limit", "integer", "The number of entries, at most 100"),
+		queryParameter("network", "string", "The network, as feed readers cannot set the Network header"),
+	}
+}
+
+// openApiRoutes specifies every route registered in main.go. A route without an entry here is left out of the
+// specification with a warning at startup, and fails TestOpenApiRoutesMatchRegisteredRoutes.
+func openApiRoutes() []openapi.Route {
+	return []openapi.Route{
+		{Method: "GET", Path: "/", OperationID: "welcome", Summary: "Welcome message", ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/health/live", Tag: "health", Summary: "Liveness", Response: &healthEntity.Health{}},
+		{Method: "GET", Path: "/health/ready", Tag: "health", Summary: "Readiness of Elasticsearch and each network, 503 when a check fails", Response: &healthEntity.Health{}},
+		{Method: "GET", Path: "/metrics", OperationID: "metrics", Summary: "Prometheus metrics", ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/openapi.json", Summary: "OpenAPI specification", Response: map[string]interface{}{}},
+
+		{Method: "GET", Path: "/address", Tag: "address", Summary: "Addresses ordered by balance",
+			Parameters: parameters("page", "size", "sort", "filters"), Headers: paginated, Response: []*explorer.Address{}},
+		{Method: "GET", Path: "/address/labels", Tag: "address", Summary: "Labelled addresses ordered by balance",
+			Parameters: withParameters(parameters("page", "size"),
+				queryParameter("key", "string", "The meta key the addresses must have, label by default or * for any key"),
+			),
+			Headers: paginated, Response: []*explorer.Address{}},
+		{Method: "GET", Path: "/address/:hash", Tag: "address", Summary: "Address", Response: &explorer.Address{}},
+		{Method: "GET", Path: "/address/:hash/meta", Tag: "address", Summary: "Address meta data", Response: map[string]string{}},
+		{Method: "GET", Path: "/address/:hash/summary", Tag: "address", Summary: "Address summary", Response: &entity.AddressSummary{}},
+		{Method: "GET", Path: "/address/:hash/history", Tag: "address", Summary: "Address history",
+			Parameters: parameters("page", "size", "cursor", "sort", "filters"), Headers: paginated, Response: []*explorer.AddressHistory{}},
+		{Method: "GET", Path: "/address/:hash/history/export", Tag: "address", Summary: "Export the full address history as CSV or NDJSON",
+			Parameters: withParameters(parameters("sort", "filters"),
+				&openapi.Parameter{Name: "format", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"csv", "ndjson"}, Default: "csv"}},
+			),
+			ContentType: "text/csv", Response: ""},
+		{Method: "GET", Path: "/address/:hash/balance", Tag: "address", Summary: "Address balance at a height or time",
+			Parameters: []*openapi.Parameter{
+				queryParameter("height", "integer", "The block height"),
+				queryParameter("time", "string", "An RFC3339 time, date or unix timestamp"),
+			},
+			Response: &entity.BalanceSnapshot{}},
+		{Method: "GET", Path: "/address/:hash/validate", Tag: "address", Summary: "Validate an address", Response: &entity.AddressValidation{}},
+		{Method: "GET", Path: "/address/:hash/staking", Tag: "address", Summary: "Address staking chart",
+			Parameters: parameters("period"), Response: []*entity.StakingGroup{}},
+		{Method: "GET", Path: "/address/:hash/staking/report", Tag: "address", Summary: "Address stakes compared with the stakes expected of its balance",
+			Parameters: []*openapi.Parameter{
+				queryParameter("from_height", "integer", "The height the report starts after, defaults to 86400 blocks before to_height"),
+				queryParameter("to_height", "integer", "The height the report ends at, defaults to the best block"),
+			},
+			Response: &entity.StakingPerformance{}},
+		{Method: "GET", Path: "/address/:hash/assoc/staking", Tag: "address", Summary: "Addresses staking for the address", Response: []string{}},
+		{Method: "GET", Path: "/balance", Tag: "address", Summary: "Balances of addresses",
+			Parameters: []*openapi.Parameter{queryParameter("addresses", "string", "Comma separated addresses")},
+			Response:   []*explorer.Address{}},
+		{Method: "GET", Path: "/addressgroup", Tag: "address", Summary: "Address activity by period",
+			Parameters: parameters("period", "count"), Response: []entity.AddressGroup{}},
+		{Method: "GET", Path: "/addresses", Tag: "address", Summary: "Address totals by period",
+			Parameters: parameters("period", "count"), Response: []entity.AddressGroupTotal{}},
+		{Method: "PUT", Path: "/auth/address/:hash/meta", Tag: "address", Summary: "Set address meta data", Security: "apiKey",
+			RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{
+				"application/x-www-form-urlencoded": {Schema: &openapi.Schema{
+					Type:       "object",
+					Properties: map[string]*openapi.Schema{"key": {Type: "string"}, "value": {Type: "string"}},
+					Required:   []string{"key"},
+				}},
+			}}},
+		{Method: "PATCH", Path: "/auth/address/:hash/meta", Tag: "address", Summary: "Update address meta data, a null value deletes the key", Security: "apiKey",
+			RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{
+				"application/json": {Schema: &openapi.Schema{Type: "object", AdditionalProperties: &openapi.Schema{Type: "string"}}},
+			}},
+			Response: map[string]string{}},
+		{Method: "DELETE", Path: "/auth/address/:hash/meta/:key", Tag: "address", Summary: "Delete an address meta key", Security: "apiKey",
+			Response: map[string]string{}},
+		{Method: "POST", Path: "/auth/address/meta/import", Tag: "address", Summary: "Import address meta data from JSON or CSV", Security: "apiKey",
+			Parameters: []*openapi.Parameter{
+				{Name: "format", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"json", "csv"}, Default: "json"}},
+			},
+			RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{
+				"application/json": {Schema: &openapi.Schema{Type: "array", Items: &openapi.Schema{
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"address": {Type: "string"},
+						"meta":    {Type: "object", AdditionalProperties: &openapi.Schema{Type: "string"}},
+					},
+				}}},
+				"text/csv": {Schema: &openapi.Schema{Type: "string", Description: "Rows of address,key,value, an empty value deletes the key"}},
+			}},
+			Response: &entity.AddressMetaImport{}},
+
+		{Method: "GET", Path: "/distribution/supply", Tag: "distribution", Summary: "Supply distribution", Response: &DistributionSupplyResponse{}},
+		{Method: "GET", Path: "/distribution/wealth", Tag: "distribution", Summary: "Wealth distribution",
+			Parameters: []*openapi.Parameter{queryParameter("groups", "string", "Comma separated group sizes")},
+			Response:   []*entity.Wealth{}},
+
+		{Method: "GET", Path: "/bestblock", Tag: "block", Summary: "Best block height", Response: uint64(0)},
+		{Method: "GET", Path: "/blockcycle", Tag: "block", Summary: "Best block cycle", Response: explorer.BlockCycle{}},
+		{Method: "GET", Path: "/blockgroup", Tag: "block", Summary: "Blocks by period",
+			Parameters: parameters("period", "count"), Response: []*blockEntity.BlockGroup{}},
+		{Method: "GET", Path: "/block", Tag: "block", Summary: "Blocks",
+			Parameters: parameters("page", "size", "cursor", "sort", "filters"), Headers: paginated, Response: []*explorer.Block{}},
+		{Method: "GET", Path: "/block/:hash", Tag: "block", Summary: "Block by hash or height", Response: &explorer.Block{}},
+		{Method: "GET", Path: "/block/:hash/cycle", Tag: "block", Summary: "Block cycle", Response: &daoEntity.LegacyBlockCycle{}},
+		{Method: "GET", Path: "/block/:hash/raw", Tag: "block", Summary: "Raw block", Response: &explorer.RawBlock{}},
+		{Method: "GET", Path: "/block/:hash/tx", Tag: "block", Summary: "Block transactions", Response: []*blockEntity.LabelledTransaction{}},
+		{Method: "GET", Path: "/tx", Tag: "transaction", Summary: "Transactions",
+			Parameters: parameters("page", "size", "cursor", "sort", "filters"), Headers: paginated, Response: []*blockEntity.LabelledTransaction{}},
+		{Method: "GET", Path: "/tx/:hash", Tag: "transaction", Summary: "Transaction", Response: &blockEntity.LabelledTransaction{}},
+		{Method: "GET", Path: "/tx/:hash/raw", Tag: "transaction", Summary: "Raw transaction", Response: &explorer.RawBlockTransaction{}},
+		{Method: "GET", Path: "/txcount", Tag: "transaction", Summary: "Transaction count", Response: int64(0)},
+
+		{Method: "GET", Path: "/staking/blocks", Tag: "staking", Summary: "Staking over the last blocks",
+			Parameters: []*openapi.Parameter{queryParameter("blocks", "integer", "The number of blocks, at most 100000")},
+			Response:   &entity.StakingBlocks{}},
+		{Method: "GET", Path: "/staking/rewards", Tag: "staking", Summary: "Staking rewards of addresses",
+			Parameters: []*openapi.Parameter{queryParameter("addresses", "string", "Comma separated addresses")},
+			Response:   []*entity.StakingReward{}},
+		{Method: "GET", Path: "/staking/estimate", Tag: "staking", Summary: "Expected staking rewards of an amount and the observed APR",
+			Parameters: []*openapi.Parameter{
+				{Name: "amount", In: "query", Required: true, Description: "The amount of NAV staking", Schema: &openapi.Schema{Type: "number"}},
+			},
+			Response: &entity.StakingEstimate{}},
+		{Method: "GET", Path: "/staking/leaderboard", Tag: "staking", Summary: "Stakers ranked by stakes with the staking concentration",
+			Parameters: []*openapi.Parameter{
+				queryParameter("blocks", "integer", "The number of blocks, defaults to 2880"),
+				queryParameter("from", "string", "The start time instead of blocks, defaults to 24 hours before to"),
+				queryParameter("to", "string", "The end time instead of blocks, defaults to now"),
+				queryParameter("limit", "integer", "The number of stakers listed, at most 1000"),
+			},
+			Response: &entity.StakingLeaderboard{}},
+
+		{Method: "GET", Path: "/softfork", Tag: "softfork", Summary: "Soft forks", Response: []*explorer.SoftFork{}},
+		{Method: "GET", Path: "/softfork/cycle", Tag: "softfork", Summary: "Soft fork cycle", Response: &softforkEntity.SoftForkCycle{}},
+
+		{Method: "GET", Path: "/dao/consensus/parameters", Tag: "dao", Summary: "Consensus parameters", Response: []explorer.ConsensusParameter{}},
+		{Method: "GET", Path: "/dao/consensus/parameters/:id", Tag: "dao", Summary: "Consensus parameter", Response: explorer.ConsensusParameter{}},
+		{Method: "GET", Path: "/dao/consultation", Tag: "dao", Summary: "Consultations",
+			Parameters: withParameters(parameters("page", "size"),
+				queryParameter("state", "integer", "The consultation state"),
+				queryParameter("consensus", "boolean", "Only consensus consultations"),
+				queryParameter("min", "integer", "The minimum answers"),
+				queryParameter("q", "string", "Full-text search of questions and answers, best matches first with highlighted fragments"),
+			),
+			Headers: paginated, Response: []*daoEntity.ConsultationMatch{}},
+		{Method: "GET", Path: "/dao/consultation/:hash", Tag: "dao", Summary: "Consultation", Response: &explorer.Consultation{}},
+		{Method: "GET", Path: "/dao/answer/:hash", Tag: "dao", Summary: "Consultation answer", Response: &explorer.Answer{}},
+		{Method: "GET", Path: "/dao/consultation/:hash/:answer/votes", Tag: "dao", Summary: "Consultation answer votes", Response: []*daoEntity.CfundVote{}},
+		{Method: "GET", Path: "/dao/events", Tag: "dao", Summary: "Proposal, payment request and consultation state changes, newest first",
+			Parameters: withParameters(parameters("page", "size"),
+				queryParameter("type", "string", "created, voting-started, accepted, rejected, paid or expired"),
+				queryParameter("element", "string", "proposal, payment-request or consultation"),
+				queryParameter("hash", "string", "The events of a proposal, payment request or consultation, including the payment requests of a proposal"),
+			),
+			Headers: paginated, Response: []*daoEntity.DaoEvent{}},
+
+		{Method: "GET", Path: "/dao/cfund/stats", Tag: "cfund", Summary: "Community fund stats", Response: &daoEntity.CfundStats{}},
+		{Method: "GET", Path: "/dao/cfund/proposal", Tag: "cfund", Summary: "Proposals",
+			Parameters: withParameters(parameters("page", "size"),
+				queryParameter("state", "integer", "The proposal state"),
+				queryParameter("votes", "boolean", "Include the votes"),
+				queryParameter("q", "string", "Full-text search of descriptions, best matches first with highlighted fragments"),
+			),
+			Headers: paginated, Response: []*daoEntity.ProposalMatch{}},
+		{Method: "GET", Path: "/dao/cfund/proposal/:hash", Tag: "cfund", Summary: "Proposal", Response: &explorer.Proposal{}},
+		{Method: "GET", Path: "/dao/cfund/proposal/:hash/votes", Tag: "cfund", Summary: "Proposal votes", Response: []*daoEntity.CfundVote{}},
+		{Method: "GET", Path: "/dao/cfund/proposal/:hash/trend", Tag: "cfund", Summary: "Proposal voting trend", Response: []*daoEntity.CfundTrend{}},
+		{Method: "GET", Path: "/dao/cfund/proposal/:hash/payment-request", Tag: "cfund", Summary: "Payment requests of a proposal", Response: []*explorer.PaymentRequest{}},
+		{Method: "GET", Path: "/dao/cfund/payment-request", Tag: "cfund", Summary: "Payment requests",
+			Parameters: withParameters(parameters("page", "size"),
+				queryParameter("proposal", "string", "The proposal hash"),
+				queryParameter("state", "integer", "The payment request state"),
+				queryParameter("votes", "boolean", "Include the votes"),
+			),
+			Headers: paginated, Response: []*explorer.PaymentRequest{}},
+		{Method: "GET", Path: "/dao/cfund/payment-request/:hash", Tag: "cfund", Summary: "Payment request", Response: &explorer.PaymentRequest{}},
+		{Method: "GET", Path: "/dao/cfund/payment-request/:hash/votes", Tag: "cfund", Summary: "Payment request votes", Response: []*daoEntity.CfundVote{}},
+		{Method: "GET", Path: "/dao/cfund/payment-request/:hash/trend", Tag: "cfund", Summary: "Payment request voting trend", Response: []*daoEntity.CfundTrend{}},
+		{Method: "GET", Path: "/dao/cfund/votes/excluded", Tag: "cfund", Summary: "Excluded votes in a voting cycle",
+			Parameters: []*openapi.Parameter{queryParameter("cycle", "integer", "The voting cycle")},
+			Response:   uint(0)},
+
+		{Method: "GET", Path: "/search", Tag: "search", Summary: "Find the type of a hash, height or address",
+			Parameters: []*openapi.Parameter{queryParameter("query", "string", "The search query")},
+			Response:   &Result{}},
+		{Method: "GET", Path: "/search/suggest", Tag: "search", Summary: "Ranked suggestions across every type for partial input",
+			Parameters: []*openapi.Parameter{
+				{Name: "query", In: "query", Required: true, Description: "A hash or address prefix, a block height or text", Schema: &openapi.Schema{Type: "string"}},
+				{Name: "limit", In: "query", Description: "The most results per type", Schema: &openapi.Schema{Type: "integer", Default: 5}},
+				queryParameter("types", "string", "Comma separated types: block, transaction, address, proposal, paymentRequest or consultation"),
+			},
+			Response: []*searchEntity.Suggestion{}},
+
+		{Method: "GET", Path: "/graphql", Tag: "graphql", Summary: "GraphQL query",
+			Parameters: []*openapi.Parameter{
+				queryParameter("query", "string", "The GraphQL query"),
+				queryParameter("operationName", "string", "The operation to execute"),
+				queryParameter("variables", "string", "JSON encoded variables"),
+			},
+			Response: map[string]interface{}{}},
+		{Method: "POST", Path: "/graphql", Tag: "graphql", Summary: "GraphQL query",
+			RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{
+				"application/json": {Schema: &openapi.Schema{
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"query":         {Type: "string"},
+						"operationName": {Type: "string"},
+						"variables":     {Type: "object"},
+					},
+					Required: []string{"query"},
+				}},
+			}},
+			Response: map[string]interface{}{}},
+
+		{Method: "GET", Path: "/supply", Tag: "block", Summary: "Supply over the last blocks",
+			Parameters: []*openapi.Parameter{
+				queryParameter("blocks", "integer", "The number of blocks"),
+				queryParameter("fill", "boolean", "Fill blocks without supply changes"),
+			},
+			Response: []blockEntity.Supply{}},
+
+		{Method: "GET", Path: "/feed/blocks", Tag: "feed", Summary: "Atom or RSS feed of the latest blocks, 503 without FEED_BASE_URL",
+			Parameters: feedParameters(), ContentType: "application/atom+xml", Response: ""},
+		{Method: "GET", Path: "/feed/proposals", Tag: "feed", Summary: "Atom or RSS feed of the newest proposals, 503 without FEED_BASE_URL",
+			Parameters: feedParameters(), ContentType: "application/atom+xml", Response: ""},
+		{Method: "GET", Path: "/feed/payment-requests", Tag: "feed", Summary: "Atom or RSS feed of the newest payment requests, 503 without FEED_BASE_URL",
+			Parameters: feedParameters(), ContentType: "application/atom+xml", Response: ""},
+		{Method: "GET", Path: "/feed/consultations", Tag: "feed", Summary: "Atom or RSS feed of the newest consultations, 503 without FEED_BASE_URL",
+			Parameters: feedParameters(), ContentType: "application/atom+xml", Response: ""},
+
+		{Method: "GET", Path: "/insight-api/status", Tag: "insight", Summary: "Insight status, the info by default",
+			Parameters: []*openapi.Parameter{
+				{Name: "q", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"getInfo", "getDifficulty", "getBestBlockHash", "getLastBlockHash"}, Default: "getInfo"}},
+			},
+			Response: map[string]interface{}{}},
+		{Method: "GET", Path: "/insight-api/sync", Tag: "insight", Summary: "Insight sync status", Response: &insightEntity.Sync{}},
+		{Method: "GET", Path: "/insight-api/block/:hash", Tag: "insight", Summary: "Insight block", Response: &insightEntity.Block{}},
+		{Method: "GET", Path: "/insight-api/block-index/:height", Tag: "insight", Summary: "Insight block hash at a height", Response: &insightEntity.BlockIndex{}},
+		{Method: "GET", Path: "/insight-api/blocks", Tag: "insight", Summary: "Insight blocks of a day, newest first",
+			Parameters: []*openapi.Parameter{
+				queryParameter("blockDate", "string", "The day formatted YYYY-MM-DD, today by default"),
+				queryParameter("limit", "integer", "The number of blocks, at most 1000"),
+			},
+			Response: &insightEntity.Blocks{}},
+		{Method: "GET", Path: "/insight-api/tx/:txid", Tag: "insight", Summary: "Insight transaction", Response: &insightEntity.Transaction{}},
+		{Method: "GET", Path: "/insight-api/rawtx/:txid", Tag: "insight", Summary: "Insight raw transaction hex", Response: &insightEntity.RawTransaction{}},
+		{Method: "GET", Path: "/insight-api/txs", Tag: "insight", Summary: "Insight transactions of a block or address in pages of ten",
+			Parameters: []*openapi.Parameter{
+				queryParameter("block", "string", "The block hash"),
+				queryParameter("address", "string", "The address, newest transactions first"),
+				queryParameter("pageNum", "integer", "The page, numbered from 0"),
+			},
+			Response: &insightEntity.Transactions{}},
+		{Method: "GET", Path: "/insight-api/addr/:addr", Tag: "insight", Summary: "Insight address with its transaction ids, newest first",
+			Parameters: []*openapi.Parameter{
+				queryParameter("noTxList", "integer", "1 to leave out the transaction ids"),
+				queryParameter("from", "integer", "The index of the first transaction id, 0 by default"),
+				queryParameter("to", "integer", "The index after the last transaction id, at most 1000 after from"),
+			},
+			Response: &insightEntity.Address{}},
+		{Method: "GET", Path: "/insight-api/addr/:addr/balance", Tag: "insight", Summary: "Insight address balance in satoshi",
+			ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/insight-api/addr/:addr/totalReceived", Tag: "insight", Summary: "Insight address total received in satoshi",
+			ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/insight-api/addr/:addr/totalSent", Tag: "insight", Summary: "Insight address total sent in satoshi",
+			ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/insight-api/addr/:addr/unconfirmedBalance", Tag: "insight", Summary: "Insight address unconfirmed balance in satoshi, always 0",
+			ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/insight-api/addr/:addr/utxo", Tag: "insight", Summary: "Insight unspent outputs of an address, newest first",
+			Response: []*insightEntity.Utxo{}},
+		{Method: "GET", Path: "/insight-api/addrs/:addrs/utxo", Tag: "insight", Summary: "Insight unspent outputs of up to 100 comma separated addresses, newest first",
+			Response: []*insightEntity.Utxo{}},
+		{Method: "POST", Path: "/insight-api/addrs/utxo", OperationID: "insight.postUnspentOutputsForAddresses", Tag: "insight", Summary: "Insight unspent outputs of up to 100 comma separated addresses, newest first",
+			RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{
+				"application/x-www-form-urlencoded": {Schema: &openapi.Schema{
+					Type:       "object",
+					Properties: map[string]*openapi.Schema{"addrs": {Type: "string"}},
+					Required:   []string{"addrs"},
+				}},
+			}},
+			Response: []*insightEntity.Utxo{}},
+
+		{Method: "GET", Path: "/esplora-api/blocks", Tag: "esplora", Summary: "Esplora ten blocks down from the tip", Response: []*esploraEntity.Block{}},
+		{Method: "GET", Path: "/esplora-api/blocks/:start_height", OperationID: "esplora.getBlocksFromHeight", Tag: "esplora", Summary: "Esplora ten blocks down from a height",
+			Response: []*esploraEntity.Block{}},
+		{Method: "GET", Path: "/esplora-api/blocks/tip/height", Tag: "esplora", Summary: "Esplora height of the best block", ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/esplora-api/blocks/tip/hash", Tag: "esplora", Summary: "Esplora hash of the best block", ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/esplora-api/block-height/:height", Tag: "esplora", Summary: "Esplora block hash at a height", ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/esplora-api/block/:hash", Tag: "esplora", Summary: "Esplora block", Response: &esploraEntity.Block{}},
+		{Method: "GET", Path: "/esplora-api/block/:hash/status", Tag: "esplora", Summary: "Esplora block status", Response: &esploraEntity.BlockStatus{}},
+		{Method: "GET", Path: "/esplora-api/block/:hash/txids", Tag: "esplora", Summary: "Esplora transaction ids of a block", Response: []string{}},
+		{Method: "GET", Path: "/esplora-api/block/:hash/txid/:index", Tag: "esplora", Summary: "Esplora transaction id at an index of a block",
+			ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/esplora-api/block/:hash/txs", Tag: "esplora", Summary: "Esplora first 25 transactions of a block",
+			Response: []*esploraEntity.Transaction{}},
+		{Method: "GET", Path: "/esplora-api/block/:hash/txs/:start_index", OperationID: "esplora.getBlockTransactionsFromIndex", Tag: "esplora",
+			Summary: "Esplora 25 transactions of a block from an index, a multiple of 25", Response: []*esploraEntity.Transaction{}},
+		{Method: "GET", Path: "/esplora-api/tx/:txid", Tag: "esplora", Summary: "Esplora transaction", Response: &esploraEntity.Transaction{}},
+		{Method: "GET", Path: "/esplora-api/tx/:txid/status", Tag: "esplora", Summary: "Esplora transaction status", Response: &esploraEntity.TransactionStatus{}},
+		{Method: "GET", Path: "/esplora-api/tx/:txid/hex", Tag: "esplora", Summary: "Esplora raw transaction hex", ContentType: "text/plain", Response: ""},
+		{Method: "GET", Path: "/esplora-api/tx/:txid/outspends", Tag: "esplora", Summary: "Esplora spending status of each output of a transaction",
+			Response: []*esploraEntity.Outspend{}},
+		{Method: "GET", Path: "/esplora-api/tx/:txid/outspend/:vout", Tag: "esplora", Summary: "Esplora spending status of an output", Response: &esploraEntity.Outspend{}},
+		{Method: "GET", Path: "/esplora-api/address/:addr", Tag: "esplora", Summary: "Esplora address stats", Response: &esploraEntity.Address{}},
+		{Method: "GET", Path: "/esplora-api/address/:addr/txs", Tag: "esplora", Summary: "Esplora newest 25 transactions of an address",
+			Response: []*esploraEntity.Transaction{}},
+		{Method: "GET", Path: "/esplora-api/address/:addr/txs/chain", OperationID: "esplora.getAddressChainTransactions", Tag: "esplora",
+			Summary: "Esplora newest 25 confirmed transactions of an address", Response: []*esploraEntity.Transaction{}},
+		{Method: "GET", Path: "/esplora-api/address/:addr/txs/chain/:last_seen", OperationID: "esplora.getAddressChainTransactionsAfter", Tag: "esplora",
+			Summary: "Esplora next 25 confirmed transactions of an address after the last seen txid", Response: []*esploraEntity.Transaction{}},
+		{Method: "GET", Path: "/esplora-api/address/:addr/txs/mempool", Tag: "esplora", Summary: "Esplora unconfirmed transactions of an address, always empty",
+			Response: []*esploraEntity.Transaction{}},
+		{Method: "GET", Path: "/esplora-api/address/:addr/utxo", Tag: "esplora", Summary: "Esplora unspent outputs of an address, oldest first",
+			Response: []*esploraEntity.Utxo{}},
+
+		{Method: "POST", Path: "/auth/watchlist", Tag: "watchlist", Summary: "Create a watchlist, the response has the webhook secret which is not returned again", Security: "apiKey",
+			RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{
+				"application/json": {Schema: &openapi.Schema{
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"url":       {Type: "string"},
+						"events":    {Type: "array", Items: &openapi.Schema{Type: "string", Enum: []interface{}{"receive", "send", "stake", "dao"}}},
+						"addresses": {Type: "array", Items: &openapi.Schema{Type: "string"}},
+						"follow":    {Type: "array", Items: &openapi.Schema{Type: "string"}},
+					},
+					Required: []string{"url"},
+				}},
+			}},
+			Status: 201, Response: &watchlistEntity.Watchlist{}},
+		{Method: "GET", Path: "/auth/watchlist", Tag: "watchlist", Summary: "Watchlists of the API key", Security: "apiKey",
+			Response: []*watchlistEntity.Watchlist{}},
+		{Method: "GET", Path: "/auth/watchlist/:id", Tag: "watchlist", Summary: "Watchlist", Security: "apiKey",
+			Response: &watchlistEntity.Watchlist{}},
+		{Method: "PATCH", Path: "/auth/watchlist/:id", Tag: "watchlist", Summary: "Update a watchlist url, events or followed DAO hashes and add or remove addresses", Security: "apiKey",
+			RequestBody: &openapi.RequestBody{Required: true, Content: map[string]*openapi.MediaType{
+				"application/json": {Schema: &openapi.Schema{
+					Type: "object",
+					Properties: map[string]*openapi.Schema{
+						"url":    {Type: "string"},
+						"events": {Type: "array", Items: &openapi.Schema{Type: "string", Enum: []interface{}{"receive", "send", "stake", "dao"}}},
+						"follow": {Type: "array", Items: &openapi.Schema{Type: "string"}},
+						"add":    {Type: "array", Items: &openapi.Schema{Type: "string"}},
+						"remove": {Type: "array", Items: &openapi.Schema{Type: "string"}},
+					},
+				}},
+			}},
+			Response: &watchlistEntity.Watchlist{}},
+		{Method: "DELETE", Path: "/auth/watchlist/:id", Tag: "watchlist", Summary: "Delete a watchlist", Security: "apiKey", Status: 204},
+		{Method: "POST", Path: "/auth/watchlist/:id/ping", Tag: "watchlist", Summary: "Deliver a ping event to the watchlist url", Security: "apiKey",
+			Status: 202, Response: &watchlistEntity.Delivery{}},
+		{Method: "GET", Path: "/auth/watchlist/:id/deliveries", Tag: "watchlist", Summary: "Recent webhook deliveries of the watchlist, newest first", Security: "apiKey",
+			Response: []*watchlistEntity.Delivery{}},
+
+		{Method: "GET", Path: "/stream/blocks", Tag: "stream", Summary: "Websocket stream of new blocks",
+			Parameters: []*openapi.Parameter{
+				queryParameter("network", "string", "The network, as browsers cannot set the Network header"),
+				queryParameter("txs", "boolean", "Include the block transactions"),
+			},
+			Status: 101, Response: streamEntity.Message{}},
+	}
+}
@@ -0,0 +1,106 @@
+package resource
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+var routeMethods = map[string]bool{"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true}
+
+// registeredRoutes reads the routes registered in main.go, following the prefixes of router groups
+func registeredRoutes(t *testing.T) map[string]bool {
+	file, err := parser.ParseFile(token.NewFileSet(), "../../main.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefixes := map[string]string{"r": ""}
+	routes := make(map[string]bool)
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			name, ok := node.Lhs[0].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if receiver, method, path, ok := routeCall(node.Rhs[0]); ok && method == "Group" {
+				prefixes[name.Name] = prefixes[receiver] + path
+			}
+		case *ast.CallExpr:
+			if receiver, method, path, ok := routeCall(node); ok && routeMethods[method] {
+				prefix, known := prefixes[receiver]
+				if !known {
+					t.Errorf("Route %s %s is registered on an unknown router %s", method, path, receiver)
+				}
+				routes[method+" "+prefix+path] = true
+			}
+		}
+		return true
+	})
+
+	return routes
+}
+
+// routeCall matches receiver.Method("path", ...)
+func routeCall(expr ast.Expr) (receiver, method, path string, ok bool) {
+	call, isCall := expr.(*ast.CallExpr)
+	if !isCall || len(call.Args) == 0 {
+		return
+	}
+	selector, isSelector := call.Fun.(*ast.SelectorExpr)
+	if !isSelector {
+		return
+	}
+	ident, isIdent := selector.X.(*ast.Ident)
+	literal, isLiteral := call.Args[0].(*ast.BasicLit)
+	if !isIdent || !isLiteral || literal.Kind != token.STRING {
+		return
+	}
+	path, err := strconv.Unquote(literal.Value)
+	if err != nil {
+		return
+	}
+
+	return ident.Name, selector.Sel.Name, path, true
+}
+
+func TestOpenApiRoutesMatchRegisteredRoutes(t *testing.T) {
+	registered := registeredRoutes(t)
+	if len(registered) == 0 {
+		t.Fatal("No routes found in main.go")
+	}
+
+	specified := make(map[string]bool)
+	for _, route := range openApiRoutes() {
+		specified[route.Method+" "+route.Path] = true
+	}
+
+	missing, extra := make([]string, 0), make([]string, 0)
+	for route := range registered {
+		if !specified[route] {
+			missing = append(missing, route)
+		}
+	}
+	for route := range specified {
+		if !registered[route] {
+			extra = append(extra, route)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	for _, route := range missing {
+		t.Errorf("Route %s is not in openApiRoutes", route)
+	}
+	for _, route := range extra {
+		t.Errorf("openApiRoutes has %s which is not registered", route)
+	}
+}
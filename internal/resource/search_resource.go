@@ -2,17 +2,23 @@ package resource
 
 import (
 	"errors"
+	"fmt"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/search"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/search/entity"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 type SearchResource struct {
 	addressService address.Service
 	blockService   block.Service
 	daoService     dao.Service
+	searchService  search.Service
 }
 
 type Result struct {
@@ -20,11 +26,12 @@ type Result struct {
 	Value string `json:"value"`
 }
 
-func NewSearchResource(addressService address.Service, blockService block.Service, daoService dao.Service) *SearchResource {
+func NewSearchResource(addressService address.Service, blockService block.Service, daoService dao.Service, searchService search.Service) *SearchResource {
 	return &SearchResource{
 		addressService,
 		blockService,
 		daoService,
+		searchService,
 	}
 }
 
@@ -59,3 +66,49 @@ func (r *SearchResource) Search(c *gin.Context) {
 
 	handleError(c, errors.New("no search result"), http.StatusNotFound)
 }
+
+// Suggest returns ranked results across every type, or the comma separated types, for partial input
+func (r *SearchResource) Suggest(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("query"))
+	if query == "" {
+		ErrorBadRequest(c, "The query is required")
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit < 1 || limit > search.MaxSuggestLimit {
+		ErrorBadRequest(c, fmt.Sprintf("The limit must be between 1 and %d", search.MaxSuggestLimit))
+		return
+	}
+
+	types := entity.SuggestionTypes
+	if value := c.Query("types"); value != "" {
+		types = make([]entity.SuggestionType, 0)
+		for _, name := range strings.Split(value, ",") {
+			suggestionType, ok := suggestionType(name)
+			if !ok {
+				ErrorBadRequest(c, "Unknown type "+name)
+				return
+			}
+			types = append(types, suggestionType)
+		}
+	}
+
+	suggestions, err := r.searchService.Suggest(c.Request.Context(), network(c), query, types, limit)
+	if err != nil {
+		errorInternalServerError(c, err.Error())
+		return
+	}
+
+	c.JSON(200, suggestions)
+}
+
+func suggestionType(name string) (entity.SuggestionType, bool) {
+	for _, suggestionType := range entity.SuggestionTypes {
+		if string(suggestionType) == strings.TrimSpace(name) {
+			return suggestionType, true
+		}
+	}
+
+	return "", false
+}
@@ -2,13 +2,17 @@ package resource
 
 import (
 	"errors"
+	"fmt"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type StakingResource struct {
@@ -56,3 +60,66 @@ func (r *StakingResource) GetStakingRewardsForAddresses(c *gin.Context) {
 
 	c.JSON(200, rewards)
 }
+
+func (r *StakingResource) GetEstimate(c *gin.Context) {
+	amount, err := strconv.ParseFloat(c.Query("amount"), 64)
+	if err != nil || amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		ErrorBadRequest(c, fmt.Sprintf("Invalid amount `%s`", c.Query("amount")))
+		return
+	}
+
+	estimate, err := r.stakingService.GetEstimate(network(c), amount)
+	if err != nil {
+		handleError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(200, estimate)
+}
+
+// GetLeaderboard ranks the stakers over the last blocks, or between the from and to times when either is given
+func (r *StakingResource) GetLeaderboard(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "25"))
+	if err != nil {
+		limit = 25
+	}
+
+	var leaderboard *entity.StakingLeaderboard
+	fromParam, hasFrom := c.GetQuery("from")
+	toParam, hasTo := c.GetQuery("to")
+	if hasFrom || hasTo {
+		end := time.Now().UTC()
+		if hasTo {
+			if end, err = parseTime(toParam); err != nil {
+				ErrorBadRequest(c, fmt.Sprintf("Invalid to `%s`", toParam))
+				return
+			}
+		}
+		start := end.Add(-24 * time.Hour)
+		if hasFrom {
+			if start, err = parseTime(fromParam); err != nil {
+				ErrorBadRequest(c, fmt.Sprintf("Invalid from `%s`", fromParam))
+				return
+			}
+		}
+		leaderboard, err = r.stakingService.GetLeaderboardByTime(network(c), start, end, limit)
+	} else {
+		blockCount, parseErr := strconv.Atoi(c.DefaultQuery("blocks", "2880"))
+		if parseErr != nil {
+			ErrorBadRequest(c, fmt.Sprintf("Invalid blocks `%s`", c.Query("blocks")))
+			return
+		}
+		leaderboard, err = r.stakingService.GetLeaderboardByBlockCount(network(c), blockCount, limit)
+	}
+
+	if err != nil {
+		if service.IsLeaderboardError(err) {
+			ErrorBadRequest(c, err.Error())
+		} else {
+			errorInternalServerError(c, err.Error())
+		}
+		return
+	}
+
+	c.JSON(200, leaderboard)
+}
@@ -0,0 +1,102 @@
+package resource
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	networkService "github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/stream"
+	"go.uber.org/zap"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	streamWriteWait  = 10 * time.Second
+	streamPongWait   = 60 * time.Second
+	streamPingPeriod = (streamPongWait * 9) / 10
+)
+
+type StreamResource struct {
+	streamService stream.Service
+	upgrader      websocket.Upgrader
+}
+
+func NewStreamResource(streamService stream.Service) *StreamResource {
+	return &StreamResource{
+		streamService: streamService,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin: func(r *http.Request) bool {
+				return true
+			},
+		},
+	}
+}
+
+func (r *StreamResource) GetBlocks(c *gin.Context) {
+	n := network(c)
+
+	// Browsers cannot set headers on a websocket handshake so the network may also be given as a query parameter
+	if name := c.Query("network"); name != "" {
+		queryNetwork, err := networkService.GetNetwork(name)
+		if err != nil {
+			errorNetworkNotAvailable(c)
+			return
+		}
+		n = queryNetwork
+	}
+
+	transactions, _ := strconv.ParseBool(c.DefaultQuery("txs", "false"))
+
+	conn, err := r.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		zap.L().With(zap.Error(err)).Error("Stream: Failed to upgrade connection")
+		return
+	}
+	defer conn.Close()
+
+	subscriber := r.streamService.Subscribe(n, transactions)
+	defer r.streamService.Unsubscribe(subscriber)
+
+	closed := make(chan bool)
+	go func() {
+		defer close(closed)
+
+		conn.SetReadLimit(512)
+		_ = conn.SetReadDeadline(time.Now().Add(streamPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(streamPongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case message, ok := <-subscriber.Messages:
+			_ = conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+			if !ok {
+				_ = conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := conn.WriteJSON(message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
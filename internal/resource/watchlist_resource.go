@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type WatchlistResource struct {
+	watchlistService watchlist.Service
+}
+
+func NewWatchlistResource(watchlistService watchlist.Service) *WatchlistResource {
+	return &WatchlistResource{watchlistService}
+}
+
+// CreateWatchlist returns the watchlist with its secret, which is not returned again
+func (r *WatchlistResource) CreateWatchlist(c *gin.Context) {
+	request := new(entity.WatchlistRequest)
+	if err := c.ShouldBindJSON(request); err != nil {
+		ErrorBadRequest(c, framework.ErrorInvalidJson.Error())
+		return
+	}
+
+	w, err := r.watchlistService.Create(network(c), credential(c), request)
+	if err != nil {
+		handleWatchlistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, w)
+}
+
+func (r *WatchlistResource) GetWatchlists(c *gin.Context) {
+	c.JSON(200, r.watchlistService.GetWatchlists(credential(c)))
+}
+
+func (r *WatchlistResource) GetWatchlist(c *gin.Context) {
+	w, err := r.watchlistService.GetWatchlist(credential(c), c.Param("id"))
+	if err != nil {
+		handleWatchlistError(c, err)
+		return
+	}
+
+	c.JSON(200, w)
+}
+
+func (r *WatchlistResource) UpdateWatchlist(c *gin.Context) {
+	change := new(entity.WatchlistChange)
+	if err := c.ShouldBindJSON(change); err != nil {
+		ErrorBadRequest(c, framework.ErrorInvalidJson.Error())
+		return
+	}
+
+	w, err := r.watchlistService.Update(credential(c), c.Param("id"), change)
+	if err != nil {
+		handleWatchlistError(c, err)
+		return
+	}
+
+	c.JSON(200, w)
+}
+
+func (r *WatchlistResource) DeleteWatchlist(c *gin.Context) {
+	if err := r.watchlistService.Delete(credential(c), c.Param("id")); err != nil {
+		handleWatchlistError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (r *WatchlistResource) PingWatchlist(c *gin.Context) {
+	delivery, err := r.watchlistService.Ping(credential(c), c.Param("id"))
+	if err != nil {
+		handleWatchlistError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func (r *WatchlistResource) GetDeliveries(c *gin.Context) {
+	deliveries, err := r.watchlistService.GetDeliveries(credential(c), c.Param("id"))
+	if err != nil {
+		handleWatchlistError(c, err)
+		return
+	}
+
+	c.JSON(200, deliveries)
+}
+
+func handleWatchlistError(c *gin.Context, err error) {
+	switch {
+	case err == watchlist.ErrWatchlistNotFound:
+		errorNotFound(c, err.Error())
+	case watchlist.IsWatchlistError(err):
+		ErrorBadRequest(c, err.Error())
+	default:
+		errorInternalServerError(c, err.Error())
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+const (
+	MaxLeaderboardBlocks = 1051200
+	MaxLeaderboardLimit  = 1000
+)
+
+var (
+	ErrLeaderboardRangeInvalid = errors.New("The leaderboard window must start before it ends")
+	ErrLeaderboardRangeSize    = fmt.Errorf("A leaderboard covers at most %d blocks", MaxLeaderboardBlocks)
+)
+
+// The top stakers whose share of the stakes is measured
+var stakingTopShares = []int{1, 10, 100}
+
+func IsLeaderboardError(err error) bool {
+	return err == ErrLeaderboardRangeInvalid || err == ErrLeaderboardRangeSize
+}
+
+func (s *stakingService) GetLeaderboardByBlockCount(n network.Network, blockCount int, limit int) (*entity.StakingLeaderboard, error) {
+	if blockCount <= 0 {
+		return nil, ErrLeaderboardRangeInvalid
+	}
+
+	bestBlock, err := s.blockRepository.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	from := uint64(0)
+	if uint64(blockCount) < bestBlock.Height {
+		from = bestBlock.Height - uint64(blockCount)
+	}
+
+	return s.getLeaderboard(n, from, bestBlock.Height, limit)
+}
+
+func (s *stakingService) GetLeaderboardByTime(n network.Network, start, end time.Time, limit int) (*entity.StakingLeaderboard, error) {
+	if !start.Before(end) {
+		return nil, ErrLeaderboardRangeInvalid
+	}
+
+	from, err := s.heightAtTime(n, start)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.heightAtTime(n, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getLeaderboard(n, from, to, limit)
+}
+
+// heightAtTime is the height of the last block at or before t, or 0 before the first block
+func (s *stakingService) heightAtTime(n network.Network, t time.Time) (uint64, error) {
+	height, err := s.blockRepository.GetHeightAtTime(n, t)
+	if err == repository.ErrBlockNotFound {
+		return 0, nil
+	}
+
+	return height, err
+}
+
+// getLeaderboard ranks the addresses staking in the blocks after from up to to, the concentration
+// is measured over every staker while only the first limit stakers are listed
+func (s *stakingService) getLeaderboard(n network.Network, from, to uint64, limit int) (*entity.StakingLeaderboard, error) {
+	if to-from > MaxLeaderboardBlocks {
+		return nil, ErrLeaderboardRangeSize
+	}
+	if limit < 1 || limit > MaxLeaderboardLimit {
+		limit = MaxLeaderboardLimit
+	}
+
+	leaderboard := &entity.StakingLeaderboard{
+		From:   from,
+		To:     to,
+		Blocks: to - from,
+		Concentration: entity.StakingConcentration{
+			Top: make([]*entity.StakingTopShare, 0),
+		},
+		Addresses: make([]*entity.Staker, 0),
+	}
+	if from >= to {
+		return leaderboard, nil
+	}
+
+	addresses, err := s.blockRepository.GetStakingAddresses(n, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stakers, err := s.addressHistoryRepository.GetStakers(n, from, to, addresses)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(stakers, func(i, j int) bool {
+		if stakers[i].Stakes != stakers[j].Stakes {
+			return stakers[i].Stakes > stakers[j].Stakes
+		}
+		return stakers[i].Address < stakers[j].Address
+	})
+
+	for _, staker := range stakers {
+		leaderboard.Stakes += staker.Stakes
+	}
+	leaderboard.Stakers = len(stakers)
+	leaderboard.Concentration = stakingConcentration(stakers, leaderboard.Stakes)
+
+	for i, staker := range stakers {
+		staker.Rank = i + 1
+		if leaderboard.Stakes > 0 {
+			staker.Share = float64(staker.Stakes) / float64(leaderboard.Stakes)
+		}
+	}
+
+	if len(stakers) > limit {
+		stakers = stakers[:limit]
+	}
+	leaderboard.Addresses = stakers
+
+	hashes := make([]string, len(stakers))
+	for i, staker := range stakers {
+		hashes[i] = staker.Address
+	}
+	labels, err := s.addressService.GetLabels(n, hashes)
+	if err != nil {
+		zap.L().With(zap.Error(err)).Error("Staking: Failed to get leaderboard labels")
+	}
+	for _, staker := range stakers {
+		staker.Label = labels[staker.Address]
+	}
+
+	return leaderboard, nil
+}
+
+// stakingConcentration measures stakers ordered by most stakes first
+func stakingConcentration(stakers []*entity.Staker, total int64) entity.StakingConcentration {
+	concentration := entity.StakingConcentration{Top: make([]*entity.StakingTopShare, 0)}
+	if total == 0 {
+		return concentration
+	}
+
+	cumulative := make([]int64, len(stakers))
+	for i, staker := range stakers {
+		cumulative[i] = staker.Stakes
+		if i > 0 {
+			cumulative[i] += cumulative[i-1]
+		}
+		if concentration.Nakamoto == 0 && cumulative[i]*2 > total {
+			concentration.Nakamoto = i + 1
+		}
+	}
+
+	// A top larger than the stakers is the share of every staker, which is only listed once
+	for _, top := range stakingTopShares {
+		if top > len(stakers) {
+			top = len(stakers)
+		}
+		concentration.Top = append(concentration.Top, &entity.StakingTopShare{
+			Stakers: top,
+			Stakes:  cumulative[top-1],
+			Share:   float64(cumulative[top-1]) / float64(total),
+		})
+		if top == len(stakers) {
+			break
+		}
+	}
+
+	// Gini over the stakes in ascending order, the stakers are in descending order
+	count := float64(len(stakers))
+	weighted := float64(0)
+	for i, staker := range stakers {
+		weighted += (count - float64(i)) * float64(staker.Stakes)
+	}
+	concentration.Gini = 2*weighted/(count*float64(total)) - (count+1)/count
+
+	return concentration
+}
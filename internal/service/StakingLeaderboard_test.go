@@ -0,0 +1,104 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+)
+
+func TestStakingConcentration(t *testing.T) {
+	stakers := func(stakes ...int64) ([]*entity.Staker, int64) {
+		result := make([]*entity.Staker, len(stakes))
+		total := int64(0)
+		for i, s := range stakes {
+			result[i] = &entity.Staker{Stakes: s}
+			total += s
+		}
+		return result, total
+	}
+	equal := make([]int64, 150)
+	for i := range equal {
+		equal[i] = 1
+	}
+
+	tests := []struct {
+		name     string
+		stakes   []int64
+		nakamoto int
+		gini     float64
+		top      []entity.StakingTopShare
+	}{
+		{
+			name: "no stakes",
+		},
+		{
+			name:     "single staker",
+			stakes:   []int64{10},
+			nakamoto: 1,
+			gini:     0,
+			top:      []entity.StakingTopShare{{Stakers: 1, Stakes: 10, Share: 1}},
+		},
+		{
+			name:     "equal stakes",
+			stakes:   []int64{5, 5, 5, 5},
+			nakamoto: 3,
+			gini:     0,
+			top:      []entity.StakingTopShare{{Stakers: 1, Stakes: 5, Share: 0.25}, {Stakers: 4, Stakes: 20, Share: 1}},
+		},
+		{
+			name:     "tie at half of the stakes needs the next staker",
+			stakes:   []int64{5, 5},
+			nakamoto: 2,
+			gini:     0,
+			top:      []entity.StakingTopShare{{Stakers: 1, Stakes: 5, Share: 0.5}, {Stakers: 2, Stakes: 10, Share: 1}},
+		},
+		{
+			name:     "half of the stakes reached within the stakers",
+			stakes:   []int64{3, 2, 2, 2, 1},
+			nakamoto: 3,
+			gini:     0.16,
+			top:      []entity.StakingTopShare{{Stakers: 1, Stakes: 3, Share: 0.3}, {Stakers: 5, Stakes: 10, Share: 1}},
+		},
+		{
+			name:     "dominant staker",
+			stakes:   []int64{9, 1},
+			nakamoto: 1,
+			gini:     0.4,
+			top:      []entity.StakingTopShare{{Stakers: 1, Stakes: 9, Share: 0.9}, {Stakers: 2, Stakes: 10, Share: 1}},
+		},
+		{
+			name:     "more stakers than the largest top",
+			stakes:   equal,
+			nakamoto: 76,
+			gini:     0,
+			top: []entity.StakingTopShare{
+				{Stakers: 1, Stakes: 1, Share: 1.0 / 150},
+				{Stakers: 10, Stakes: 10, Share: 10.0 / 150},
+				{Stakers: 100, Stakes: 100, Share: 100.0 / 150},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			concentration := stakingConcentration(stakers(tt.stakes...))
+
+			if concentration.Nakamoto != tt.nakamoto {
+				t.Errorf("Nakamoto = %d, want %d", concentration.Nakamoto, tt.nakamoto)
+			}
+			if math.Abs(concentration.Gini-tt.gini) > 1e-9 {
+				t.Errorf("Gini = %f, want %f", concentration.Gini, tt.gini)
+			}
+			if len(concentration.Top) != len(tt.top) {
+				t.Fatalf("Top has %d shares, want %d", len(concentration.Top), len(tt.top))
+			}
+			for i, top := range concentration.Top {
+				want := tt.top[i]
+				if top.Stakers != want.Stakers || top.Stakes != want.Stakes || math.Abs(top.Share-want.Share) > 1e-9 {
+					t.Errorf("Top[%d] = %+v, want %+v", i, *top, want)
+				}
+			}
+		})
+	}
+}
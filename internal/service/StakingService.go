@@ -1,25 +1,165 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/cache"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/consensus"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"time"
 )
 
+var (
+	ErrStakingAmountInvalid       = errors.New("Amount must be greater than 0")
+	ErrStakingWindowsMissing      = errors.New("No staking estimate windows are configured")
+	ErrGenerationPerBlockNotFound = errors.New("Generation per block consensus parameter not found")
+)
+
+const yearDuration = 365 * 24 * time.Hour
+
 type StakingService interface {
 	GetStakingRewardsForAddresses(n network.Network, addresses []string) ([]*entity.StakingReward, error)
+	GetEstimate(n network.Network, amount float64) (*entity.StakingEstimate, error)
+	GetLeaderboardByBlockCount(n network.Network, blockCount int, limit int) (*entity.StakingLeaderboard, error)
+	GetLeaderboardByTime(n network.Network, start, end time.Time, limit int) (*entity.StakingLeaderboard, error)
 }
 
 func NewStakingService(
 	addressHistoryRepository repository.AddressHistoryRepository,
+	blockRepository repository.BlockRepository,
+	addressService address.Service,
+	blockService block.Service,
+	consensusService consensus.Service,
+	cache *cache.Cache,
+	blockTime time.Duration,
+	windows []int,
 ) StakingService {
-	return &stakingService{addressHistoryRepository}
+	return &stakingService{addressHistoryRepository, blockRepository, addressService, blockService, consensusService, cache, blockTime, windows}
 }
 
 type stakingService struct {
 	addressHistoryRepository repository.AddressHistoryRepository
+	blockRepository          repository.BlockRepository
+	addressService           address.Service
+	blockService             block.Service
+	consensusService         consensus.Service
+	cache                    *cache.Cache
+	blockTime                time.Duration
+	windows                  []int
 }
 
 func (s *stakingService) GetStakingRewardsForAddresses(n network.Network, addresses []string) ([]*entity.StakingReward, error) {
 	return s.addressHistoryRepository.StakingRewardsForAddresses(n, addresses)
 }
+
+// GetEstimate observes the staking rewards over each window and estimates the staking of amount NAV.
+// The network weight is the stakable balance of the addresses staking in the longest window, as short
+// windows miss the smaller stakers.
+func (s *stakingService) GetEstimate(n network.Network, amount float64) (*entity.StakingEstimate, error) {
+	if amount <= 0 {
+		return nil, ErrStakingAmountInvalid
+	}
+	if len(s.windows) == 0 {
+		return nil, ErrStakingWindowsMissing
+	}
+
+	generation := s.consensusService.GetParameter(n, consensus.GENERATION_PER_BLOCK)
+	if generation == nil {
+		return nil, ErrGenerationPerBlockNotFound
+	}
+
+	estimate := &entity.StakingEstimate{
+		Amount:  amount,
+		Windows: make([]*entity.StakingWindow, 0),
+		Network: entity.StakingNetwork{
+			BlockTime:          s.blockTime.Seconds(),
+			GenerationPerBlock: float64(generation.Value) / 100000000,
+		},
+	}
+	blocksPerYear := yearDuration.Seconds() / s.blockTime.Seconds()
+
+	var longest *entity.StakingWindow
+	var longestFees float64
+	for _, blocks := range s.windows {
+		stakingBlocks, err := s.getStakingByBlockCount(n, blocks)
+		if err != nil {
+			return nil, err
+		}
+
+		window := &entity.StakingWindow{
+			Blocks: int(stakingBlocks.To - stakingBlocks.From),
+			From:   stakingBlocks.From,
+			To:     stakingBlocks.To,
+			Stakes: stakingBlocks.BlockCount,
+			Weight: stakingBlocks.Staking + stakingBlocks.ColdStaking,
+		}
+		window.Rewards = float64(window.Stakes)*estimate.Network.GenerationPerBlock + stakingBlocks.Fees
+		if window.Weight > 0 && window.Blocks > 0 {
+			window.Apr = window.Rewards / window.Weight * blocksPerYear / float64(window.Blocks) * 100
+		}
+		estimate.Windows = append(estimate.Windows, window)
+
+		if longest == nil || window.Blocks > longest.Blocks {
+			longest = window
+			longestFees = stakingBlocks.Fees
+		}
+	}
+
+	estimate.Height = longest.To
+	estimate.Network.Weight = longest.Weight
+	if longest.Blocks > 0 {
+		estimate.Network.FeesPerBlock = longestFees / float64(longest.Blocks)
+	}
+
+	supply, err := s.blockService.GetSupply(n, 1, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(supply) != 0 {
+		balance := supply[0].Balance
+		estimate.Network.Supply = float64(balance.Public+balance.Private+balance.Wrapped) / 100000000
+	}
+	if estimate.Network.Supply > 0 {
+		estimate.Network.Participation = estimate.Network.Weight / estimate.Network.Supply
+	}
+
+	// Each block is staked with a chance proportional to the amount's share of the weight it joins
+	expected := &estimate.Expected
+	expected.Probability = amount / (estimate.Network.Weight + amount)
+	expected.StakesPerYear = expected.Probability * blocksPerYear
+	expected.StakesPerDay = expected.StakesPerYear / 365
+	if expected.StakesPerDay > 0 {
+		expected.DaysBetweenStakes = 1 / expected.StakesPerDay
+	}
+	expected.AnnualReward = expected.StakesPerYear * (estimate.Network.GenerationPerBlock + estimate.Network.FeesPerBlock)
+	expected.Apr = expected.AnnualReward / amount * 100
+
+	return estimate, nil
+}
+
+// getStakingByBlockCount caches the staking over each window at the best height, so a window is aggregated once
+// per block it is requested at, and the windows of past heights expire rather than being refreshed
+func (s *stakingService) getStakingByBlockCount(n network.Network, blocks int) (*entity.StakingBlocks, error) {
+	bestBlock, err := s.blockRepository.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	args := fmt.Sprintf("%d.%d", blocks, bestBlock.Height)
+	result, err := s.cache.Get(
+		s.cache.GenerateKey(n.String(), "stakingByBlockCount", args, nil),
+		func() (interface{}, error) {
+			return s.addressService.GetStakingByBlockCount(n, blocks)
+		},
+		cache.DefaultExpiration,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*entity.StakingBlocks), nil
+}
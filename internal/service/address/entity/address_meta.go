@@ -0,0 +1,20 @@
+package entity
+
+// LabelKey is the meta key holding the display label of an address, e.g. an exchange name
+const LabelKey = "label"
+
+// AddressMetaChange sets each meta key of the address to its value, a null value deletes the key
+type AddressMetaChange struct {
+	Address string             `json:"address"`
+	Meta    map[string]*string `json:"meta"`
+}
+
+type AddressMetaImport struct {
+	Updated int                       `json:"updated"`
+	Failed  []*AddressMetaImportError `json:"failed"`
+}
+
+type AddressMetaImportError struct {
+	Address string `json:"address"`
+	Error   string `json:"error"`
+}
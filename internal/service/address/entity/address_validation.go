@@ -0,0 +1,27 @@
+package entity
+
+type AddressType string
+
+var (
+	AddressPubKeyHash    AddressType = "pubkeyhash"
+	AddressScriptHash    AddressType = "scripthash"
+	AddressColdStaking   AddressType = "cold_staking"
+	AddressColdStakingV2 AddressType = "cold_staking_v2"
+	AddressBlsct         AddressType = "blsct"
+)
+
+type AddressValidation struct {
+	Address string      `json:"address"`
+	Valid   bool        `json:"valid"`
+	Type    AddressType `json:"type,omitempty"`
+	Network string      `json:"network,omitempty"`
+	Error   string      `json:"error,omitempty"`
+
+	PubKeyHash      string `json:"pubkey_hash,omitempty"`
+	ScriptHash      string `json:"script_hash,omitempty"`
+	StakingKeyHash  string `json:"staking_key_hash,omitempty"`
+	SpendingKeyHash string `json:"spending_key_hash,omitempty"`
+	VotingKeyHash   string `json:"voting_key_hash,omitempty"`
+	ViewKey         string `json:"view_key,omitempty"`
+	SpendKey        string `json:"spend_key,omitempty"`
+}
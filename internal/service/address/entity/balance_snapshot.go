@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"time"
+)
+
+type BalanceSnapshot struct {
+	Hash         string                   `json:"hash"`
+	Height       uint64                   `json:"height,omitempty"`
+	Time         *time.Time               `json:"time,omitempty"`
+	Spendable    int64                    `json:"spendable"`
+	Stakable     int64                    `json:"stakable"`
+	VotingWeight int64                    `json:"voting_weight"`
+	History      *explorer.AddressHistory `json:"history"`
+}
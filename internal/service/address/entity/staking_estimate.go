@@ -0,0 +1,40 @@
+package entity
+
+type StakingEstimate struct {
+	Amount   float64            `json:"amount"`
+	Height   uint64             `json:"height"`
+	Network  StakingNetwork     `json:"network"`
+	Windows  []*StakingWindow   `json:"windows"`
+	Expected StakingExpectation `json:"expected"`
+}
+
+// StakingNetwork is the network staking weight over the longest window, in NAV
+type StakingNetwork struct {
+	Weight             float64 `json:"weight"`
+	Supply             float64 `json:"supply"`
+	Participation      float64 `json:"participation"`
+	BlockTime          float64 `json:"blockTime"`
+	GenerationPerBlock float64 `json:"generationPerBlock"`
+	FeesPerBlock       float64 `json:"feesPerBlock"`
+}
+
+// StakingWindow is the observed staking over the last Blocks blocks
+type StakingWindow struct {
+	Blocks  int     `json:"blocks"`
+	From    uint64  `json:"from"`
+	To      uint64  `json:"to"`
+	Stakes  int64   `json:"stakes"`
+	Weight  float64 `json:"weight"`
+	Rewards float64 `json:"rewards"`
+	Apr     float64 `json:"apr"`
+}
+
+// StakingExpectation is the expected staking of the amount given the network weight
+type StakingExpectation struct {
+	Probability       float64 `json:"probability"`
+	StakesPerDay      float64 `json:"stakesPerDay"`
+	StakesPerYear     float64 `json:"stakesPerYear"`
+	DaysBetweenStakes float64 `json:"daysBetweenStakes"`
+	AnnualReward      float64 `json:"annualReward"`
+	Apr               float64 `json:"apr"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+// StakingLeaderboard ranks the addresses staking in the blocks after From up to To by their stakes
+type StakingLeaderboard struct {
+	From          uint64               `json:"from"`
+	To            uint64               `json:"to"`
+	Blocks        uint64               `json:"blocks"`
+	Stakes        int64                `json:"stakes"`
+	Stakers       int                  `json:"stakers"`
+	Concentration StakingConcentration `json:"concentration"`
+	Addresses     []*Staker            `json:"addresses"`
+}
+
+// StakingConcentration measures how evenly the stakes are spread over the stakers.
+// Nakamoto is the fewest stakers with more than half of the stakes and Gini is 0 when every staker has the same stakes.
+type StakingConcentration struct {
+	Nakamoto int                `json:"nakamoto"`
+	Gini     float64            `json:"gini"`
+	Top      []*StakingTopShare `json:"top"`
+}
+
+type StakingTopShare struct {
+	Stakers int     `json:"stakers"`
+	Stakes  int64   `json:"stakes"`
+	Share   float64 `json:"share"`
+}
+
+// Staker rewards are in satoshi
+type Staker struct {
+	Rank       int     `json:"rank"`
+	Address    string  `json:"address"`
+	Label      string  `json:"label,omitempty"`
+	Stakes     int64   `json:"stakes"`
+	ColdStakes int64   `json:"coldStakes"`
+	Rewards    int64   `json:"rewards"`
+	Share      float64 `json:"share"`
+}
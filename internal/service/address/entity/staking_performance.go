@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// StakingPerformance compares the stakes of an address in the blocks after From up to To with the stakes
+// expected of its stakable balance. Balances and rewards are in satoshi, network weights are in NAV.
+type StakingPerformance struct {
+	Hash           string                      `json:"hash"`
+	From           uint64                      `json:"from"`
+	To             uint64                      `json:"to"`
+	Blocks         uint64                      `json:"blocks"`
+	Stakes         int64                       `json:"stakes"`
+	Expected       float64                     `json:"expected"`
+	Luck           float64                     `json:"luck"`
+	AverageBalance int64                       `json:"averageBalance"`
+	Rewards        int64                       `json:"rewards"`
+	Hot            StakingSplit                `json:"hot"`
+	Cold           StakingSplit                `json:"cold"`
+	LongestGap     *StakingGap                 `json:"longestGap,omitempty"`
+	Periods        []*StakingPerformancePeriod `json:"periods"`
+}
+
+type StakingSplit struct {
+	Stakes  int64 `json:"stakes"`
+	Rewards int64 `json:"rewards"`
+}
+
+// StakingGap is the longest run of blocks between two consecutive stakes
+type StakingGap struct {
+	From     uint64    `json:"from"`
+	To       uint64    `json:"to"`
+	Blocks   uint64    `json:"blocks"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Duration int64     `json:"duration"`
+}
+
+type StakingPerformancePeriod struct {
+	From     uint64  `json:"from"`
+	To       uint64  `json:"to"`
+	Stakes   int64   `json:"stakes"`
+	Expected float64 `json:"expected"`
+	Balance  int64   `json:"balance"`
+	Weight   float64 `json:"weight"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type UnspentOutput struct {
+	Address      string    `json:"address"`
+	Txid         string    `json:"txid"`
+	Vout         int       `json:"vout"`
+	ScriptPubKey string    `json:"script_pub_key"`
+	Value        uint64    `json:"value"`
+	Height       uint64    `json:"height"`
+	BlockHash    string    `json:"block_hash"`
+	Time         time.Time `json:"time"`
+}
@@ -0,0 +1,257 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/audit"
+	auditEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/audit/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	log "github.com/sirupsen/logrus"
+	"regexp"
+	"sort"
+)
+
+const (
+	maxMetaKeyLength   = 64
+	maxMetaValueLength = 1024
+	MaxMetaImport      = 1000
+)
+
+var (
+	ErrAddressMetaEmpty        = errors.New("Address meta has no changes")
+	ErrAddressMetaKeyInvalid   = fmt.Errorf("Address meta keys must be 1 to %d letters, digits, _ or -", maxMetaKeyLength)
+	ErrAddressMetaValueInvalid = fmt.Errorf("Address meta values must be at most %d characters", maxMetaValueLength)
+	ErrAddressMetaImportSize   = fmt.Errorf("At most %d addresses can be imported at once", MaxMetaImport)
+)
+
+// Keys become Elasticsearch field names so dots, which would create nested objects, are not allowed
+var metaKeyPattern = regexp.MustCompile(fmt.Sprintf("^[a-zA-Z0-9_-]{1,%d}$", maxMetaKeyLength))
+
+func IsAddressMetaError(err error) bool {
+	return err == ErrAddressMetaEmpty ||
+		err == ErrAddressMetaKeyInvalid ||
+		err == ErrAddressMetaValueInvalid ||
+		err == ErrAddressMetaImportSize
+}
+
+// UpdateAddressMeta applies every change to the address meta in a single write, a nil value deletes the key.
+// Each change is added to the audit trail, with the error when the write fails.
+func (s *service) UpdateAddressMeta(n network.Network, hash string, meta map[string]*string, trail *audit.Trail) (map[string]string, error) {
+	if err := validateAddressMeta(meta); err != nil {
+		return nil, err
+	}
+
+	metas, err := s.addressRepository.GetAddressMeta(n, []string{hash})
+	if err != nil {
+		return nil, err
+	}
+	current, ok := metas[hash]
+	if !ok {
+		return nil, repository.ErrAddressNotFound
+	}
+
+	entries, update := addressMetaChanges(n, current, meta)
+	if update == nil {
+		return current.Meta, nil
+	}
+
+	log.WithFields(log.Fields{
+		"address": hash,
+		"changes": len(entries),
+	}).Info("Updating Address Meta")
+
+	errs, err := s.addressRepository.UpdateAddressMeta(n, []*repository.AddressMetaUpdate{update})
+	if err == nil {
+		err = errs[0]
+	}
+	if err != nil {
+		for _, entry := range entries {
+			entry.Error = err.Error()
+		}
+		trail.Add(entries...)
+		return nil, err
+	}
+	trail.Add(entries...)
+
+	return updatedAddressMeta(update), nil
+}
+
+// ImportAddressMeta reads the meta of every address in one search and writes the changes in one bulk request.
+// A failed address does not stop the import, and the changes of an address listed twice are merged in order.
+func (s *service) ImportAddressMeta(n network.Network, changes []*entity.AddressMetaChange, trail *audit.Trail) (*entity.AddressMetaImport, error) {
+	if len(changes) > MaxMetaImport {
+		return nil, ErrAddressMetaImportSize
+	}
+
+	result := &entity.AddressMetaImport{Failed: make([]*entity.AddressMetaImportError, 0)}
+	fail := func(hash string, err error) {
+		result.Failed = append(result.Failed, &entity.AddressMetaImportError{Address: hash, Error: err.Error()})
+	}
+
+	hashes := make([]string, 0)
+	byAddress := make(map[string]map[string]*string)
+	for _, change := range changes {
+		if err := validateAddressMeta(change.Meta); err != nil {
+			fail(change.Address, err)
+			continue
+		}
+		if _, ok := byAddress[change.Address]; !ok {
+			hashes = append(hashes, change.Address)
+			byAddress[change.Address] = make(map[string]*string)
+		}
+		for key, value := range change.Meta {
+			byAddress[change.Address][key] = value
+		}
+	}
+	if len(hashes) == 0 {
+		return result, nil
+	}
+
+	metas, err := s.addressRepository.GetAddressMeta(n, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]*repository.AddressMetaUpdate, 0)
+	updateEntries := make([][]*auditEntity.Entry, 0)
+	for _, hash := range hashes {
+		current, ok := metas[hash]
+		if !ok {
+			fail(hash, repository.ErrAddressNotFound)
+			continue
+		}
+
+		entries, update := addressMetaChanges(n, current, byAddress[hash])
+		if update == nil {
+			result.Updated++
+			continue
+		}
+		updates = append(updates, update)
+		updateEntries = append(updateEntries, entries)
+	}
+	if len(updates) == 0 {
+		return result, nil
+	}
+
+	log.WithFields(log.Fields{
+		"addresses": len(updates),
+	}).Info("Importing Address Meta")
+
+	errs, err := s.addressRepository.UpdateAddressMeta(n, updates)
+	for i, update := range updates {
+		updateErr := err
+		if err == nil {
+			updateErr = errs[i]
+		}
+
+		if updateErr != nil {
+			for _, entry := range updateEntries[i] {
+				entry.Error = updateErr.Error()
+			}
+			fail(update.Meta.Hash, updateErr)
+		} else {
+			result.Updated++
+		}
+		trail.Add(updateEntries[i]...)
+	}
+
+	return result, nil
+}
+
+func validateAddressMeta(meta map[string]*string) error {
+	if len(meta) == 0 {
+		return ErrAddressMetaEmpty
+	}
+	for key, value := range meta {
+		if !metaKeyPattern.MatchString(key) {
+			return ErrAddressMetaKeyInvalid
+		}
+		if value != nil && len(*value) > maxMetaValueLength {
+			return ErrAddressMetaValueInvalid
+		}
+	}
+
+	return nil
+}
+
+// addressMetaChanges returns an audit entry for each key the meta changes, in key order,
+// and the update setting and removing those keys, or nil when nothing changes
+func addressMetaChanges(n network.Network, current *repository.AddressMeta, meta map[string]*string) ([]*auditEntity.Entry, *repository.AddressMetaUpdate) {
+	keys := make([]string, 0, len(meta))
+	for key := range meta {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]*auditEntity.Entry, 0)
+	update := &repository.AddressMetaUpdate{Meta: current, Set: map[string]string{}, Remove: []string{}}
+	for _, key := range keys {
+		entry := &auditEntity.Entry{
+			Action:   auditEntity.ActionAddressMetaUpdate,
+			Network:  n.Name,
+			Address:  current.Hash,
+			Key:      key,
+			NewValue: meta[key],
+		}
+		if oldValue, ok := current.Meta[key]; ok {
+			if meta[key] != nil && *meta[key] == oldValue {
+				continue
+			}
+			entry.OldValue = &oldValue
+		} else if meta[key] == nil {
+			continue
+		}
+
+		if meta[key] == nil {
+			entry.Action = auditEntity.ActionAddressMetaDelete
+			update.Remove = append(update.Remove, key)
+		} else {
+			update.Set[key] = *meta[key]
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return entries, update
+}
+
+// updatedAddressMeta is the meta the update leaves the address with
+func updatedAddressMeta(update *repository.AddressMetaUpdate) map[string]string {
+	meta := make(map[string]string)
+	for key, value := range update.Meta.Meta {
+		meta[key] = value
+	}
+	for _, key := range update.Remove {
+		delete(meta, key)
+	}
+	for key, value := range update.Set {
+		meta[key] = value
+	}
+
+	return meta
+}
+
+// GetMetaAddresses returns the addresses with the meta key, or with any meta when the key is empty
+func (s *service) GetMetaAddresses(n network.Network, key string, pagination framework.Pagination) ([]*explorer.Address, int64, error) {
+	if key != "" && !metaKeyPattern.MatchString(key) {
+		return nil, 0, ErrAddressMetaKeyInvalid
+	}
+
+	return s.addressRepository.GetAddressesWithMeta(n, key, pagination.Size(), pagination.Page())
+}
+
+// GetLabels returns the label of each labelled address
+func (s *service) GetLabels(n network.Network, addresses []string) (map[string]string, error) {
+	if len(addresses) == 0 {
+		return make(map[string]string), nil
+	}
+
+	return s.addressRepository.GetMetaValues(n, entity.LabelKey, addresses)
+}
@@ -1,13 +1,16 @@
 package address
 
 import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/cache"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/audit"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/group"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
 	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
 	log "github.com/sirupsen/logrus"
+	"time"
 )
 
 type Service interface {
@@ -16,14 +19,23 @@ type Service interface {
 	GetAddressSummary(n network.Network, hash string) (*entity.AddressSummary, error)
 	GetStakingChart(n network.Network, period string, address string) ([]*entity.StakingGroup, error)
 	GetStakingByBlockCount(n network.Network, blockCount int) (*entity.StakingBlocks, error)
+	GetStakingPerformance(n network.Network, hash string, from, to uint64) (*entity.StakingPerformance, error)
 	GetAddressGroups(n network.Network, period *group.Period, count int) ([]entity.AddressGroup, error)
 	GetAddressGroupsTotal(n network.Network, period *group.Period, count int) ([]entity.AddressGroupTotal, error)
 	GetHistory(n network.Network, hash string, request framework.RestRequest) ([]*explorer.AddressHistory, int64, error)
+	ExportHistory(n network.Network, hash string, request framework.RestRequest, callback func(history *explorer.AddressHistory) error) error
+	GetBalanceAtHeight(n network.Network, hash string, height uint64) (*entity.BalanceSnapshot, error)
+	GetBalanceAtTime(n network.Network, hash string, t time.Time) (*entity.BalanceSnapshot, error)
 	GetAssociatedStakingAddresses(n network.Network, address string) ([]string, error)
 	GetNamedAddresses(n network.Network, addresses []string) ([]*explorer.Address, error)
-	ValidateAddress(n network.Network, hash string) (bool, error)
+	GetUnspentOutputs(n network.Network, hashes []string) ([]*entity.UnspentOutput, error)
+	ValidateAddress(n network.Network, hash string) (*entity.AddressValidation, error)
 	GetPublicWealthDistribution(n network.Network, groups []int) ([]*entity.Wealth, error)
-	PutAddressMeta(n network.Network, address, key, value string) error
+	PutAddressMeta(n network.Network, address, key, value string, trail *audit.Trail) error
+	UpdateAddressMeta(n network.Network, hash string, meta map[string]*string, trail *audit.Trail) (map[string]string, error)
+	ImportAddressMeta(n network.Network, changes []*entity.AddressMetaChange, trail *audit.Trail) (*entity.AddressMetaImport, error)
+	GetMetaAddresses(n network.Network, key string, pagination framework.Pagination) ([]*explorer.Address, int64, error)
+	GetLabels(n network.Network, addresses []string) (map[string]string, error)
 }
 
 type service struct {
@@ -31,6 +43,7 @@ type service struct {
 	addressHistoryRepository   repository.AddressHistoryRepository
 	blockRepository            repository.BlockRepository
 	blockTransactionRepository repository.BlockTransactionRepository
+	cache                      *cache.Cache
 }
 
 func NewAddressService(
@@ -38,12 +51,14 @@ func NewAddressService(
 	addressHistoryRepository repository.AddressHistoryRepository,
 	blockRepository repository.BlockRepository,
 	blockTransactionRepository repository.BlockTransactionRepository,
+	cache *cache.Cache,
 ) Service {
 	return &service{
 		addressRepository,
 		addressHistoryRepository,
 		blockRepository,
 		blockTransactionRepository,
+		cache,
 	}
 }
 
@@ -66,6 +81,53 @@ func (s *service) GetHistory(n network.Network, hash string, request framework.R
 	return s.addressHistoryRepository.GetHistoryByHash(n, hash, request.Pagination(), request.Sort(), request.Filters())
 }
 
+func (s *service) ExportHistory(n network.Network, hash string, request framework.RestRequest, callback func(history *explorer.AddressHistory) error) error {
+	return s.addressHistoryRepository.ExportHistoryByHash(n, hash, request.Sort(), request.Filters(), callback)
+}
+
+func (s *service) GetBalanceAtHeight(n network.Network, hash string, height uint64) (*entity.BalanceSnapshot, error) {
+	history, err := s.addressHistoryRepository.GetLatestByHashAtHeight(n, hash, height)
+	snapshot, err := s.createBalanceSnapshot(n, hash, history, err)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Height = height
+
+	return snapshot, nil
+}
+
+func (s *service) GetBalanceAtTime(n network.Network, hash string, t time.Time) (*entity.BalanceSnapshot, error) {
+	history, err := s.addressHistoryRepository.GetLatestByHashAtTime(n, hash, t)
+	snapshot, err := s.createBalanceSnapshot(n, hash, history, err)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Time = &t
+
+	return snapshot, nil
+}
+
+func (s *service) createBalanceSnapshot(n network.Network, hash string, history *explorer.AddressHistory, err error) (*entity.BalanceSnapshot, error) {
+	if err == repository.ErrAddressHistoryNotFound {
+		// An address with history after the requested point had a zero balance
+		if _, err := s.addressHistoryRepository.GetFirstByHash(n, hash); err != nil {
+			return nil, err
+		}
+		return &entity.BalanceSnapshot{Hash: hash}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.BalanceSnapshot{
+		Hash:         hash,
+		Spendable:    history.Balance.Spendable,
+		Stakable:     history.Balance.Stakable,
+		VotingWeight: history.Balance.VotingWeight,
+		History:      history,
+	}, nil
+}
+
 func (s *service) GetAddressSummary(n network.Network, hash string) (*entity.AddressSummary, error) {
 	h, err := s.addressRepository.GetAddressByHash(n, hash)
 	if err != nil {
@@ -182,8 +244,8 @@ func (s *service) GetNamedAddresses(n network.Network, addresses []string) ([]*e
 	return s.addressRepository.GetBalancesForAddresses(n, addresses)
 }
 
-func (s *service) ValidateAddress(n network.Network, hash string) (bool, error) {
-	return true, nil
+func (s *service) ValidateAddress(n network.Network, hash string) (*entity.AddressValidation, error) {
+	return Validate(n.Name, hash), nil
 }
 
 func (s *service) GetPublicWealthDistribution(n network.Network, groups []int) ([]*entity.Wealth, error) {
@@ -215,30 +277,14 @@ func (s *service) UpdateCreatedAt(n network.Network, address *explorer.Address)
 	}
 }
 
-func (s *service) PutAddressMeta(n network.Network, hash, key, value string) error {
-	address, err := s.GetAddress(n, hash)
-	if err != nil {
-		return err
-	}
-
-	if address.Meta == nil {
-		address.Meta = map[string]string{}
+// PutAddressMeta sets the meta key or deletes it when the value is empty
+func (s *service) PutAddressMeta(n network.Network, hash, key, value string, trail *audit.Trail) error {
+	meta := map[string]*string{key: nil}
+	if value != "" {
+		meta[key] = &value
 	}
 
-	if value == "" {
-		log.WithFields(log.Fields{
-			"address": hash,
-			"key":     key,
-		}).Info("Deleting Address Meta")
-		delete(address.Meta, key)
-	} else {
-		log.WithFields(log.Fields{
-			"address": hash,
-			"key":     key,
-			"value":   value,
-		}).Info("Updating Address Meta")
-		address.Meta[key] = value
-	}
+	_, err := s.UpdateAddressMeta(n, hash, meta, trail)
 
-	return s.addressRepository.UpdateAddress(n, address)
+	return err
 }
@@ -0,0 +1,228 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/cache"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"sync"
+)
+
+const (
+	DefaultStakingReportBlocks = 86400
+	MaxStakingReportBlocks     = 1051200
+	stakingReportPeriods       = 30
+	// stakingWeightWorkers bounds the period weights looked up at once, each is two Elasticsearch queries
+	stakingWeightWorkers = 4
+)
+
+var (
+	ErrStakingReportRangeInvalid = errors.New("The staking report from_height must be before to_height")
+	ErrStakingReportRangeSize    = fmt.Errorf("A staking report covers at most %d blocks", MaxStakingReportBlocks)
+)
+
+func IsStakingReportError(err error) bool {
+	return err == ErrStakingReportRangeInvalid || err == ErrStakingReportRangeSize
+}
+
+// GetStakingPerformance compares the stakes of an address in the blocks after from up to to with the stakes its
+// stakable balance would be expected to win. A to of 0 is the best block and a from of 0 is DefaultStakingReportBlocks
+// before to. The range is split into periods, and in each period every block is expected to be staked with a chance
+// of the address's average balance in the weight of the addresses staking in that period.
+func (s *service) GetStakingPerformance(n network.Network, hash string, from, to uint64) (*entity.StakingPerformance, error) {
+	bestBlock, err := s.blockRepository.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+	if to == 0 || to > bestBlock.Height {
+		to = bestBlock.Height
+	}
+	if from == 0 && to > DefaultStakingReportBlocks {
+		from = to - DefaultStakingReportBlocks
+	}
+	if from >= to {
+		return nil, ErrStakingReportRangeInvalid
+	}
+	if to-from > MaxStakingReportBlocks {
+		return nil, ErrStakingReportRangeSize
+	}
+
+	snapshot, err := s.GetBalanceAtHeight(n, hash, from)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.StakingPerformance{
+		Hash:    hash,
+		From:    from,
+		To:      to,
+		Blocks:  to - from,
+		Periods: stakingPerformancePeriods(from, to),
+	}
+	size := report.Periods[0].To - report.Periods[0].From
+
+	// The stakable balance applies to the blocks after the height it was last changed at
+	weighted := make([]float64, len(report.Periods))
+	balance := snapshot.Stakable
+	height := from
+	advance := func(to uint64) {
+		for height < to {
+			i := (height - from) / size
+			end := report.Periods[i].To
+			if end > to {
+				end = to
+			}
+			weighted[i] += float64(balance) * float64(end-height)
+			height = end
+		}
+	}
+
+	var lastStake *explorer.AddressHistory
+	err = s.addressHistoryRepository.ExportHistoryByHash(
+		n,
+		hash,
+		// Replayed in order so the balance after each height is the last change at it
+		framework.NewSort([]framework.SortOption{
+			framework.NewSortOption("height", framework.NewSortDirection("asc", true)),
+			framework.NewSortOption("txindex", framework.NewSortDirection("asc", true)),
+		}),
+		framework.NewFilters(framework.FilterOptions{
+			framework.NewFilterExpression("height", framework.FilterGreaterThan, false, []interface{}{from}),
+			framework.NewFilterExpression("height", framework.FilterLessThanOrEqual, false, []interface{}{to}),
+		}),
+		func(history *explorer.AddressHistory) error {
+			// A block is staked with the balance held before it
+			advance(history.Height)
+			balance = history.Balance.Stakable
+
+			if !history.Stake {
+				return nil
+			}
+
+			report.Periods[(history.Height-from-1)/size].Stakes++
+			report.Stakes++
+			report.Rewards += history.Changes.Stakable
+			split := &report.Hot
+			if history.ColdStake {
+				split = &report.Cold
+			}
+			split.Stakes++
+			split.Rewards += history.Changes.Stakable
+
+			if lastStake != nil {
+				gap := history.Height - lastStake.Height
+				if report.LongestGap == nil || gap > report.LongestGap.Blocks {
+					report.LongestGap = &entity.StakingGap{
+						From:     lastStake.Height,
+						To:       history.Height,
+						Blocks:   gap,
+						Start:    lastStake.Time,
+						End:      history.Time,
+						Duration: int64(history.Time.Sub(lastStake.Time).Seconds()),
+					}
+				}
+			}
+			lastStake = history
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	advance(to)
+
+	failures := make([]error, len(report.Periods))
+	workers := make(chan struct{}, stakingWeightWorkers)
+	var wg sync.WaitGroup
+	for i, period := range report.Periods {
+		period.Balance = int64(weighted[i] / float64(period.To-period.From))
+
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(i int, period *entity.StakingPerformancePeriod) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			period.Weight, failures[i] = s.getStakingWeight(n, period.From, period.To)
+		}(i, period)
+	}
+	wg.Wait()
+
+	totalWeighted := float64(0)
+	for i, period := range report.Periods {
+		if failures[i] != nil {
+			return nil, failures[i]
+		}
+		totalWeighted += weighted[i]
+
+		// An address that did not stake in the period is missing from the period's weight
+		balance := float64(period.Balance) / 100000000
+		weight := period.Weight
+		if period.Stakes == 0 {
+			weight += balance
+		}
+		if weight > 0 {
+			period.Expected = float64(period.To-period.From) * balance / weight
+		}
+		report.Expected += period.Expected
+	}
+
+	report.AverageBalance = int64(totalWeighted / float64(report.Blocks))
+	if report.Expected > 0 {
+		report.Luck = float64(report.Stakes) / report.Expected
+	}
+
+	return report, nil
+}
+
+// getStakingWeight is the weight of the addresses staking in the period. The blocks of a period are all
+// indexed, so its weight is cached by the period alone and rebuilt when the cached weight expires.
+func (s *service) getStakingWeight(n network.Network, from, to uint64) (float64, error) {
+	result, err := s.cache.Get(
+		s.cache.GenerateKey(n.String(), "stakingWeight", fmt.Sprintf("%d.%d", from, to), nil),
+		func() (interface{}, error) {
+			addresses, err := s.blockRepository.GetStakingAddresses(n, from, to)
+			if err != nil {
+				return nil, err
+			}
+			if len(addresses) == 0 {
+				return float64(0), nil
+			}
+
+			stakingBlocks, err := s.addressHistoryRepository.GetStakingRange(n, from, to, addresses)
+			if err != nil {
+				return nil, err
+			}
+
+			return stakingBlocks.Staking + stakingBlocks.ColdStaking, nil
+		},
+		cache.DefaultExpiration,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.(float64), nil
+}
+
+// stakingPerformancePeriods splits the blocks after from up to to into at most stakingReportPeriods equal periods,
+// the last period is shorter when the blocks do not divide evenly
+func stakingPerformancePeriods(from, to uint64) []*entity.StakingPerformancePeriod {
+	size := (to - from + stakingReportPeriods - 1) / stakingReportPeriods
+
+	periods := make([]*entity.StakingPerformancePeriod, 0)
+	for start := from; start < to; start += size {
+		end := start + size
+		if end > to {
+			end = to
+		}
+		periods = append(periods, &entity.StakingPerformancePeriod{From: start, To: end})
+	}
+
+	return periods
+}
@@ -0,0 +1,62 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+)
+
+const MaxUnspentAddresses = 100
+
+var (
+	ErrUnspentAddressesEmpty = errors.New("At least one address is required")
+	ErrUnspentAddressesSize  = fmt.Errorf("Unspent outputs can be listed for at most %d addresses", MaxUnspentAddresses)
+)
+
+func IsUnspentError(err error) bool {
+	return err == ErrUnspentAddressesEmpty || err == ErrUnspentAddressesSize
+}
+
+// GetUnspentOutputs returns the unredeemed public outputs paying any of the addresses, oldest first.
+// An output paying more than one of the addresses, such as a cold staking output, is listed under the first.
+func (s *service) GetUnspentOutputs(n network.Network, hashes []string) ([]*entity.UnspentOutput, error) {
+	if len(hashes) == 0 {
+		return nil, ErrUnspentAddressesEmpty
+	}
+	if len(hashes) > MaxUnspentAddresses {
+		return nil, ErrUnspentAddressesSize
+	}
+
+	txs, err := s.blockTransactionRepository.GetUnspentTransactions(n, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]*entity.UnspentOutput, 0)
+	for _, tx := range txs {
+		for _, vout := range tx.Vout {
+			if vout.Redeemed || vout.Private {
+				continue
+			}
+			for _, hash := range hashes {
+				if !vout.HasAddress(hash) {
+					continue
+				}
+				outputs = append(outputs, &entity.UnspentOutput{
+					Address:      hash,
+					Txid:         tx.Txid,
+					Vout:         vout.N,
+					ScriptPubKey: vout.ScriptPubKey.Hex,
+					Value:        vout.ValueSat,
+					Height:       tx.Height,
+					BlockHash:    tx.BlockHash,
+					Time:         tx.Time,
+				})
+				break
+			}
+		}
+	}
+
+	return outputs, nil
+}
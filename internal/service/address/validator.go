@@ -0,0 +1,177 @@
+package address
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+	"math/big"
+)
+
+const (
+	keyHashLength  = 20
+	blsKeyLength   = 48
+	checksumLength = 4
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+var (
+	ErrAddressInvalidEncoding = errors.New("Address is not valid base58")
+	ErrAddressInvalidChecksum = errors.New("Address checksum is invalid")
+	ErrAddressUnknownVersion  = errors.New("Address version is not recognised")
+)
+
+type addressFormat struct {
+	addressType entity.AddressType
+	version     []byte
+	keys        int
+	keyLength   int
+}
+
+// addressFormats are the formats of the network with the version bytes of its config, a type without a version is not recognised
+func addressFormats(networkName string) []addressFormat {
+	versions, ok := config.Get().Addresses[networkName]
+	if !ok {
+		return nil
+	}
+
+	formats := make([]addressFormat, 0)
+	for _, format := range []addressFormat{
+		{entity.AddressPubKeyHash, versions.PubKeyHash, 1, keyHashLength},
+		{entity.AddressScriptHash, versions.ScriptHash, 1, keyHashLength},
+		{entity.AddressColdStaking, versions.ColdStaking, 2, keyHashLength},
+		{entity.AddressColdStakingV2, versions.ColdStakingV2, 3, keyHashLength},
+		{entity.AddressBlsct, versions.Blsct, 2, blsKeyLength},
+	} {
+		if len(format.version) != 0 {
+			formats = append(formats, format)
+		}
+	}
+
+	return formats
+}
+
+// Validate decodes a base58check address and reports its type, network and embedded keys.
+// The address is only valid when it belongs to the requested network.
+func Validate(networkName string, hash string) *entity.AddressValidation {
+	validation := &entity.AddressValidation{Address: hash}
+
+	payload, err := decodeBase58Check(hash)
+	if err != nil {
+		validation.Error = err.Error()
+		return validation
+	}
+
+	format, formatNetwork := findFormat(networkName, payload)
+	if format == nil {
+		validation.Error = ErrAddressUnknownVersion.Error()
+		return validation
+	}
+
+	validation.Type = format.addressType
+	validation.Network = formatNetwork
+
+	keys := payload[len(format.version):]
+	key := func(i int) string {
+		return hex.EncodeToString(keys[i*format.keyLength : (i+1)*format.keyLength])
+	}
+
+	switch format.addressType {
+	case entity.AddressPubKeyHash:
+		validation.PubKeyHash = key(0)
+	case entity.AddressScriptHash:
+		validation.ScriptHash = key(0)
+	case entity.AddressColdStaking:
+		validation.StakingKeyHash = key(0)
+		validation.SpendingKeyHash = key(1)
+	case entity.AddressColdStakingV2:
+		validation.StakingKeyHash = key(0)
+		validation.SpendingKeyHash = key(1)
+		validation.VotingKeyHash = key(2)
+	case entity.AddressBlsct:
+		validation.ViewKey = key(0)
+		validation.SpendKey = key(1)
+	}
+
+	if formatNetwork != networkName {
+		validation.Error = fmt.Sprintf("Address is for the %s network", formatNetwork)
+		return validation
+	}
+
+	validation.Valid = true
+
+	return validation
+}
+
+// findFormat prefers the requested network, as networks such as testnet and devnet may share version bytes
+func findFormat(networkName string, payload []byte) (*addressFormat, string) {
+	if format := matchFormat(addressFormats(networkName), payload); format != nil {
+		return format, networkName
+	}
+
+	for _, name := range []string{"mainnet", "testnet", "devnet"} {
+		if format := matchFormat(addressFormats(name), payload); format != nil {
+			return format, name
+		}
+	}
+
+	return nil, ""
+}
+
+func matchFormat(formats []addressFormat, payload []byte) *addressFormat {
+	for i := range formats {
+		format := &formats[i]
+		if len(payload) == len(format.version)+format.keys*format.keyLength && bytes.HasPrefix(payload, format.version) {
+			return format
+		}
+	}
+
+	return nil
+}
+
+func decodeBase58Check(address string) ([]byte, error) {
+	decoded, err := decodeBase58(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decoded) <= checksumLength {
+		return nil, ErrAddressInvalidEncoding
+	}
+
+	payload := decoded[:len(decoded)-checksumLength]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:checksumLength], decoded[len(decoded)-checksumLength:]) {
+		return nil, ErrAddressInvalidChecksum
+	}
+
+	return payload, nil
+}
+
+func decodeBase58(value string) ([]byte, error) {
+	if value == "" {
+		return nil, ErrAddressInvalidEncoding
+	}
+
+	result := big.NewInt(0)
+	radix := big.NewInt(58)
+	for _, r := range value {
+		index := bytes.IndexRune([]byte(base58Alphabet), r)
+		if index == -1 {
+			return nil, ErrAddressInvalidEncoding
+		}
+		result.Mul(result, radix)
+		result.Add(result, big.NewInt(int64(index)))
+	}
+
+	leadingZeros := 0
+	for leadingZeros < len(value) && value[leadingZeros] == base58Alphabet[0] {
+		leadingZeros++
+	}
+
+	return append(make([]byte, leadingZeros), result.Bytes()...), nil
+}
@@ -0,0 +1,169 @@
+package address
+
+import (
+	"os"
+	"testing"
+
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address/entity"
+)
+
+// The addresses encode the key hashes of the secp256k1 public keys 1G and 2G,
+// 751e76e8199196d454941c45d1b3a323f1433bd6 and 06afd46bcdfd22ef94ac122aa11f241244a37ecc,
+// which are the bitcoin addresses 1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH and 1cMh228HTCiwS8ZsaakH8A8wze1JR5ZsP
+const (
+	keyHash1 = "751e76e8199196d454941c45d1b3a323f1433bd6"
+	keyHash2 = "06afd46bcdfd22ef94ac122aa11f241244a37ecc"
+)
+
+// The xNAV addresses encode the compressed BLS12-381 G1 points 1G and 2G as the view and spend keys
+const (
+	blsKey1 = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+	blsKey2 = "a572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e"
+
+	mainnetBlsct = "xNUQvnjwG9JeWftvifLEUtEpDBWRRxmqKEzKG5eAZDL1rKPfT6KTCU5aJDNrjYZM3FzJBf8B8y7h2obheWtcWfJ91MaDga2xZUcMwceoZc44bqXf7dLeQZng4zjtjvFuA6fQgDNDYdT"
+	testnetBlsct = "26iDEkGZPx3k21QMsHkKGdauU71nhNRQeFqiMWpKRhcotLRA4GukfPYPyFmVMY4PJKDoiVrY7ojjNyDAh7JJMwHBdy64xRKPs1HagcwHgrSRueS55mwGBaDA819Q49PTsxrWNMsxvogA"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name            string
+		network         string
+		address         string
+		valid           bool
+		addressType     entity.AddressType
+		addressNetwork  string
+		pubKeyHash      string
+		stakingKeyHash  string
+		spendingKeyHash string
+		votingKeyHash   string
+		viewKey         string
+		spendKey        string
+		err             string
+	}{
+		{
+			name: "mainnet pubkeyhash", network: "mainnet", address: "NWbEjugszdRCVHaaX1mDXVqgUr6Yk1uQ8U",
+			valid: true, addressType: entity.AddressPubKeyHash, addressNetwork: "mainnet", pubKeyHash: keyHash1,
+		},
+		{
+			name: "mainnet scripthash", network: "mainnet", address: "bDLdPFMcdnbCTGyvxEtqVnJ1SsYBhB9WhA",
+			valid: true, addressType: entity.AddressScriptHash, addressNetwork: "mainnet",
+		},
+		{
+			name: "mainnet cold staking", network: "mainnet", address: "Xo24Sb6t8YtU54zvYBh14JXjFFwS3P9tjZdzVU4NKzdeSeAWRR9Ytxf2gqXZT",
+			valid: true, addressType: entity.AddressColdStaking, addressNetwork: "mainnet", stakingKeyHash: keyHash1, spendingKeyHash: keyHash2,
+		},
+		{
+			name: "mainnet cold staking v2", network: "mainnet", address: "4DbjqrbwQSXkpZmBWCwt1gaS5t4NALKZCGM7fLTTDMtb5QCuYtge5mYGfLFxuX6vrUn1SDq6xCJ72apGbXwC4Yfak",
+			valid: true, addressType: entity.AddressColdStakingV2, addressNetwork: "mainnet", stakingKeyHash: keyHash1, spendingKeyHash: keyHash2, votingKeyHash: keyHash1,
+		},
+		{
+			name: "testnet pubkeyhash", network: "testnet", address: "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r",
+			valid: true, addressType: entity.AddressPubKeyHash, addressNetwork: "testnet", pubKeyHash: keyHash1,
+		},
+		{
+			name: "testnet scripthash", network: "testnet", address: "2MsragJSbSp2TEPTYfosDKhWLJs8tcdHVy3",
+			valid: true, addressType: entity.AddressScriptHash, addressNetwork: "testnet",
+		},
+		{
+			name: "testnet cold staking", network: "testnet", address: "D8xk8g558R8wkNJFZJmZTQnwcTmdjxSa7iMjcU5tjeDG5aS7FJS7jvpQzwvq6",
+			valid: true, addressType: entity.AddressColdStaking, addressNetwork: "testnet", stakingKeyHash: keyHash1, spendingKeyHash: keyHash2,
+		},
+		{
+			name: "testnet cold staking v2", network: "testnet", address: "3s8JY7H7niEumwFvRxraXiyyYkW1Zb7sq54SxG66ZjC12CrzqQYDesRLsLDJJUvTeYAtmD5WFZU7cimNhJYz6wuD2",
+			valid: true, addressType: entity.AddressColdStakingV2, addressNetwork: "testnet", stakingKeyHash: keyHash1, spendingKeyHash: keyHash2, votingKeyHash: keyHash1,
+		},
+		{
+			name: "mainnet blsct", network: "mainnet", address: mainnetBlsct,
+			valid: true, addressType: entity.AddressBlsct, addressNetwork: "mainnet", viewKey: blsKey1, spendKey: blsKey2,
+		},
+		{
+			name: "testnet blsct", network: "testnet", address: testnetBlsct,
+			valid: true, addressType: entity.AddressBlsct, addressNetwork: "testnet", viewKey: blsKey1, spendKey: blsKey2,
+		},
+		{
+			name: "devnet shares the testnet versions", network: "devnet", address: "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r",
+			valid: true, addressType: entity.AddressPubKeyHash, addressNetwork: "devnet", pubKeyHash: keyHash1,
+		},
+		{
+			name: "mainnet address on testnet", network: "testnet", address: "NWbEjugszdRCVHaaX1mDXVqgUr6Yk1uQ8U",
+			addressType: entity.AddressPubKeyHash, addressNetwork: "mainnet", pubKeyHash: keyHash1, err: "Address is for the mainnet network",
+		},
+		{
+			name: "testnet cold staking on mainnet", network: "mainnet", address: "D8xk8g558R8wkNJFZJmZTQnwcTmdjxSa7iMjcU5tjeDG5aS7FJS7jvpQzwvq6",
+			addressType: entity.AddressColdStaking, addressNetwork: "testnet", stakingKeyHash: keyHash1, spendingKeyHash: keyHash2, err: "Address is for the testnet network",
+		},
+		{
+			name: "mainnet blsct on testnet", network: "testnet", address: mainnetBlsct,
+			addressType: entity.AddressBlsct, addressNetwork: "mainnet", viewKey: blsKey1, spendKey: blsKey2, err: "Address is for the mainnet network",
+		},
+		{
+			name: "bitcoin address", network: "mainnet", address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+			err: ErrAddressUnknownVersion.Error(),
+		},
+		{
+			name: "invalid checksum", network: "mainnet", address: "NWbEjugszdRCVHaaX1mDXVqgUr6Yk1uQ8V",
+			err: ErrAddressInvalidChecksum.Error(),
+		},
+		{
+			name: "invalid base58", network: "mainnet", address: "NWbEjugszdRCVHaaX1mDXVqgUr6Yk1uQ80",
+			err: ErrAddressInvalidEncoding.Error(),
+		},
+		{
+			name: "empty", network: "mainnet", address: "",
+			err: ErrAddressInvalidEncoding.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation := Validate(tt.network, tt.address)
+
+			if validation.Valid != tt.valid {
+				t.Errorf("Valid = %v, want %v", validation.Valid, tt.valid)
+			}
+			if validation.Error != tt.err {
+				t.Errorf("Error = %q, want %q", validation.Error, tt.err)
+			}
+			if validation.Type != tt.addressType {
+				t.Errorf("Type = %q, want %q", validation.Type, tt.addressType)
+			}
+			if validation.Network != tt.addressNetwork {
+				t.Errorf("Network = %q, want %q", validation.Network, tt.addressNetwork)
+			}
+			if validation.PubKeyHash != tt.pubKeyHash {
+				t.Errorf("PubKeyHash = %q, want %q", validation.PubKeyHash, tt.pubKeyHash)
+			}
+			if validation.StakingKeyHash != tt.stakingKeyHash {
+				t.Errorf("StakingKeyHash = %q, want %q", validation.StakingKeyHash, tt.stakingKeyHash)
+			}
+			if validation.SpendingKeyHash != tt.spendingKeyHash {
+				t.Errorf("SpendingKeyHash = %q, want %q", validation.SpendingKeyHash, tt.spendingKeyHash)
+			}
+			if validation.VotingKeyHash != tt.votingKeyHash {
+				t.Errorf("VotingKeyHash = %q, want %q", validation.VotingKeyHash, tt.votingKeyHash)
+			}
+			if validation.ViewKey != tt.viewKey {
+				t.Errorf("ViewKey = %q, want %q", validation.ViewKey, tt.viewKey)
+			}
+			if validation.SpendKey != tt.spendKey {
+				t.Errorf("SpendKey = %q, want %q", validation.SpendKey, tt.spendKey)
+			}
+		})
+	}
+}
+
+func TestValidateAddressVersionsFromConfig(t *testing.T) {
+	// Mainnet configured with the testnet pubkeyhash version recognises testnet pubkeyhash addresses
+	os.Setenv("ADDRESS_VERSIONS_MAINNET", "pubkeyhash=111")
+	defer os.Unsetenv("ADDRESS_VERSIONS_MAINNET")
+
+	validation := Validate("mainnet", "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r")
+	if !validation.Valid || validation.Network != "mainnet" {
+		t.Errorf("Valid = %v, Network = %q, want valid on mainnet", validation.Valid, validation.Network)
+	}
+
+	validation = Validate("mainnet", "NWbEjugszdRCVHaaX1mDXVqgUr6Yk1uQ8U")
+	if validation.Error != ErrAddressUnknownVersion.Error() {
+		t.Errorf("Error = %q, want %q", validation.Error, ErrAddressUnknownVersion.Error())
+	}
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+type Action string
+
+var (
+	ActionAddressMetaUpdate Action = "address.meta.update"
+	ActionAddressMetaDelete Action = "address.meta.delete"
+)
+
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Actor    string    `json:"actor"`
+	Action   Action    `json:"action"`
+	Network  string    `json:"network"`
+	Address  string    `json:"address,omitempty"`
+	Key      string    `json:"key,omitempty"`
+	OldValue *string   `json:"old_value"`
+	NewValue *string   `json:"new_value"`
+	Status   int       `json:"status"`
+	Error    string    `json:"error,omitempty"`
+}
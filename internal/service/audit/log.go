@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"encoding/json"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/audit/entity"
+	"os"
+	"sync"
+	"time"
+)
+
+type Log interface {
+	Record(entry *entity.Entry) error
+}
+
+type fileLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileLog appends each entry to the file at path as a line of JSON
+func NewFileLog(path string) Log {
+	return &fileLog{path: path}
+}
+
+func (l *fileLog) Record(entry *entity.Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	// The write is only acknowledged once it is on disk
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
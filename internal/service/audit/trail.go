@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/audit/entity"
+	"sync"
+)
+
+// Trail collects the entries of a request, which are recorded once the response status is known
+type Trail struct {
+	entries []*entity.Entry
+	mu      sync.Mutex
+}
+
+func NewTrail() *Trail {
+	return &Trail{entries: make([]*entity.Entry, 0)}
+}
+
+func (t *Trail) Add(entries ...*entity.Entry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.entries = append(t.entries, entries...)
+}
+
+func (t *Trail) Entries() []*entity.Entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]*entity.Entry{}, t.entries...)
+}
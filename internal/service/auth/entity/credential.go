@@ -0,0 +1,28 @@
+package entity
+
+type Role string
+
+var (
+	RoleAdmin      Role = "admin"
+	RoleMetaEditor Role = "meta-editor"
+	RoleWatchlist  Role = "watchlist"
+)
+
+// Credential is an API key holder, only the SHA-256 hash of the key is stored
+type Credential struct {
+	Name    string `json:"name"`
+	KeyHash string `json:"key_hash"`
+	Roles   []Role `json:"roles"`
+	Tier    string `json:"tier,omitempty"`
+}
+
+// HasRole reports whether the credential has the role, admins have every role
+func (c *Credential) HasRole(role Role) bool {
+	for _, r := range c.Roles {
+		if r == role || r == RoleAdmin {
+			return true
+		}
+	}
+
+	return false
+}
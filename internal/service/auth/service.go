@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/auth/entity"
+	"io/ioutil"
+	"os"
+)
+
+var (
+	ErrApiKeyMissing = errors.New("API key is required")
+	ErrApiKeyInvalid = errors.New("API key is not valid")
+)
+
+type Service interface {
+	Authenticate(key string) (*entity.Credential, error)
+}
+
+type service struct {
+	credentials map[string]*entity.Credential
+}
+
+func NewAuthService(credentials []*entity.Credential) Service {
+	s := &service{credentials: make(map[string]*entity.Credential)}
+	for _, credential := range credentials {
+		s.credentials[credential.KeyHash] = credential
+	}
+
+	return s
+}
+
+// Authenticate finds the credential by the hash of the key so the key itself is never compared
+func (s *service) Authenticate(key string) (*entity.Credential, error) {
+	if key == "" {
+		return nil, ErrApiKeyMissing
+	}
+
+	credential, ok := s.credentials[HashKey(key)]
+	if !ok {
+		return nil, ErrApiKeyInvalid
+	}
+
+	return credential, nil
+}
+
+// LoadCredentials reads a JSON array of credentials, a missing file has no credentials
+func LoadCredentials(path string) ([]*entity.Credential, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make([]*entity.Credential, 0), nil
+		}
+		return nil, err
+	}
+
+	credentials := make([]*entity.Credential, 0)
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func HashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,28 @@
+package block
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/cache"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"go.uber.org/zap"
+)
+
+// RefreshCache returns a BlockHandler which rebuilds the network's RefreshingExpiration cache items,
+// registered with OnBestBlock so a poll catching up several blocks refreshes them once
+func RefreshCache(c *cache.Cache) BlockHandler {
+	return func(n network.Network, block *explorer.Block) {
+		refreshed, failed := c.Refresh(n.String())
+
+		logger := zap.L().With(
+			zap.String("network", n.String()),
+			zap.Uint64("height", block.Height),
+			zap.Int("refreshed", refreshed),
+			zap.Int("failed", failed),
+		)
+		if failed != 0 {
+			logger.Warn("Cache: Refresh completed with failures")
+		} else {
+			logger.Debug("Cache: Refreshed")
+		}
+	}
+}
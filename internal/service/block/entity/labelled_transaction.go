@@ -0,0 +1,9 @@
+package entity
+
+import "github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+
+// LabelledTransaction is a transaction with the labels of its input and output addresses, keyed by address
+type LabelledTransaction struct {
+	*explorer.BlockTransaction
+	Labels map[string]string `json:"labels,omitempty"`
+}
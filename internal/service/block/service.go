@@ -19,6 +19,7 @@ type Service interface {
 	GetTransactions(n network.Network, request framework.RestRequest) ([]*explorer.BlockTransaction, int64, error)
 	GetTransactionsByBlockHash(n network.Network, blockHash string) ([]*explorer.BlockTransaction, error)
 	GetTransactionByHash(n network.Network, hash string) (*explorer.BlockTransaction, error)
+	GetTransactionsByTxids(n network.Network, txids []string) ([]*explorer.BlockTransaction, error)
 	GetRawTransactionByHash(n network.Network, hash string) (*explorer.RawBlockTransaction, error)
 	GetSupply(n network.Network, blocks int, fillEmpty bool) ([]entity.Supply, error)
 }
@@ -103,6 +104,10 @@ func (s *service) GetTransactionByHash(n network.Network, hash string) (*explore
 	return s.transactionRepo.GetTransactionByHash(n, hash)
 }
 
+func (s *service) GetTransactionsByTxids(n network.Network, txids []string) ([]*explorer.BlockTransaction, error) {
+	return s.transactionRepo.GetTransactionsByTxids(n, txids)
+}
+
 func (s *service) GetRawTransactionByHash(n network.Network, hash string) (*explorer.RawBlockTransaction, error) {
 	return s.transactionRepo.GetRawTransactionByHash(n, hash)
 }
@@ -0,0 +1,157 @@
+package block
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// The maximum number of blocks published in a single poll when the watcher falls behind
+const maxBlocksPerPoll uint64 = 100
+
+type BlockHandler func(n network.Network, block *explorer.Block)
+
+type Watcher interface {
+	OnBlock(handler BlockHandler)
+	OnBestBlock(handler BlockHandler)
+	Start()
+	Stop()
+}
+
+type watcher struct {
+	blockRepo repository.BlockRepository
+	interval  time.Duration
+	handlers  []BlockHandler
+	best      []BlockHandler
+	heights   map[string]uint64
+	mu        sync.RWMutex
+	stop      chan bool
+	wg        sync.WaitGroup
+}
+
+func NewWatcher(blockRepo repository.BlockRepository, interval time.Duration) Watcher {
+	return &watcher{
+		blockRepo: blockRepo,
+		interval:  interval,
+		handlers:  make([]BlockHandler, 0),
+		best:      make([]BlockHandler, 0),
+		heights:   make(map[string]uint64),
+	}
+}
+
+func (w *watcher) OnBlock(handler BlockHandler) {
+	w.mu.Lock()
+	w.handlers = append(w.handlers, handler)
+	w.mu.Unlock()
+}
+
+// OnBestBlock handlers are called once per poll with the best block, after the blocks up to it are published
+func (w *watcher) OnBestBlock(handler BlockHandler) {
+	w.mu.Lock()
+	w.best = append(w.best, handler)
+	w.mu.Unlock()
+}
+
+func (w *watcher) Start() {
+	w.mu.Lock()
+	if w.stop != nil {
+		w.mu.Unlock()
+		return
+	}
+	stop := make(chan bool)
+	w.stop = stop
+	w.wg.Add(1)
+	w.mu.Unlock()
+
+	zap.S().Infof("Watcher: Polling for new blocks every %s", w.interval)
+
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				for _, n := range network.GetNetworks() {
+					w.poll(n)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits for a poll in progress to finish publishing, so no handler is called once it returns
+func (w *watcher) Stop() {
+	w.mu.Lock()
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+	w.mu.Unlock()
+
+	w.wg.Wait()
+}
+
+func (w *watcher) poll(n network.Network) {
+	bestBlock, err := w.blockRepo.GetBestBlock(n)
+	if err != nil {
+		zap.L().With(zap.Error(err), zap.String("network", n.String())).Warn("Watcher: Failed to get best block")
+		return
+	}
+	bestBlock.Best = true
+
+	w.mu.Lock()
+	height, seen := w.heights[n.String()]
+	w.heights[n.String()] = bestBlock.Height
+	w.mu.Unlock()
+
+	if !seen || bestBlock.Height == height {
+		return
+	}
+
+	// A lower best block means a reorg, so only the new best block is published
+	defer w.publishBest(n, bestBlock)
+	if bestBlock.Height < height || bestBlock.Height-height > maxBlocksPerPoll {
+		w.publish(n, bestBlock)
+		return
+	}
+
+	for h := height + 1; h < bestBlock.Height; h++ {
+		block, err := w.blockRepo.GetBlockByHeight(n, h)
+		if err != nil {
+			zap.L().With(zap.Error(err), zap.Uint64("height", h)).Error("Watcher: Failed to get block")
+			continue
+		}
+		block.Confirmations = bestBlock.Height - block.Height
+		w.publish(n, block)
+	}
+	w.publish(n, bestBlock)
+}
+
+func (w *watcher) publish(n network.Network, block *explorer.Block) {
+	zap.L().With(zap.String("network", n.String()), zap.Uint64("height", block.Height)).Debug("Watcher: New block")
+
+	w.mu.RLock()
+	handlers := w.handlers
+	w.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(n, block)
+	}
+}
+
+func (w *watcher) publishBest(n network.Network, block *explorer.Block) {
+	w.mu.RLock()
+	handlers := w.best
+	w.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(n, block)
+	}
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+)
+
+type DaoEventType string
+
+var (
+	DaoEventCreated       DaoEventType = "created"
+	DaoEventVotingStarted DaoEventType = "voting-started"
+	DaoEventAccepted      DaoEventType = "accepted"
+	DaoEventRejected      DaoEventType = "rejected"
+	DaoEventPaid          DaoEventType = "paid"
+	DaoEventExpired       DaoEventType = "expired"
+)
+
+var DaoEventTypes = []DaoEventType{
+	DaoEventCreated,
+	DaoEventVotingStarted,
+	DaoEventAccepted,
+	DaoEventRejected,
+	DaoEventPaid,
+	DaoEventExpired,
+}
+
+type DaoElement string
+
+var (
+	DaoProposal       DaoElement = "proposal"
+	DaoPaymentRequest DaoElement = "payment-request"
+	DaoConsultation   DaoElement = "consultation"
+)
+
+var DaoElements = []DaoElement{DaoProposal, DaoPaymentRequest, DaoConsultation}
+
+// DaoEvent is a change of state of a proposal, payment request or consultation seen at a best block.
+// Description is the question of a consultation.
+type DaoEvent struct {
+	Id             uint64       `json:"id"`
+	Network        string       `json:"network"`
+	Type           DaoEventType `json:"type"`
+	Element        DaoElement   `json:"element"`
+	Hash           string       `json:"hash"`
+	ProposalHash   string       `json:"proposalHash,omitempty"`
+	Description    string       `json:"description"`
+	Status         string       `json:"status"`
+	PreviousStatus string       `json:"previousStatus,omitempty"`
+	Height         uint64       `json:"height"`
+	Time           time.Time    `json:"time"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+
+// ProposalMatch is a proposal found by a full-text search with the matching fragments of each field
+type ProposalMatch struct {
+	*explorer.Proposal
+	Highlight map[string][]string `json:"highlight,omitempty"`
+}
+
+// ConsultationMatch is a consultation found by a full-text search with the matching fragments of its question and answers
+type ConsultationMatch struct {
+	*explorer.Consultation
+	Highlight map[string][]string `json:"highlight,omitempty"`
+}
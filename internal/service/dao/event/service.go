@@ -0,0 +1,334 @@
+package event
+
+import (
+	"errors"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"go.uber.org/zap"
+	"sync"
+)
+
+// The indexer can update an element after its block is published, so the last few blocks are searched again
+const rescanBlocks uint64 = 10
+
+var (
+	ErrDaoEventTypeInvalid = errors.New("Type must be created, voting-started, accepted, rejected, paid or expired")
+	ErrDaoElementInvalid   = errors.New("Element must be proposal, payment-request or consultation")
+)
+
+func IsDaoEventError(err error) bool {
+	return err == ErrDaoEventTypeInvalid || err == ErrDaoElementInvalid
+}
+
+// statusEvents is the event of entering each status, a status without an event only changes the state
+var statusEvents = map[entity.DaoElement]map[string]entity.DaoEventType{
+	entity.DaoProposal: {
+		explorer.ProposalPending.Status:         entity.DaoEventVotingStarted,
+		explorer.ProposalAccepted.Status:        entity.DaoEventAccepted,
+		explorer.ProposalPendingFunds.Status:    entity.DaoEventAccepted,
+		explorer.ProposalRejected.Status:        entity.DaoEventRejected,
+		explorer.ProposalExpired.Status:         entity.DaoEventExpired,
+		explorer.ProposalAcceptedExpired.Status: entity.DaoEventExpired,
+	},
+	entity.DaoPaymentRequest: {
+		explorer.PaymentRequestPending.Status:  entity.DaoEventVotingStarted,
+		explorer.PaymentRequestAccepted.Status: entity.DaoEventAccepted,
+		explorer.PaymentRequestRejected.Status: entity.DaoEventRejected,
+		explorer.PaymentRequestExpired.Status:  entity.DaoEventExpired,
+		explorer.PaymentRequestPaid.Status:     entity.DaoEventPaid,
+	},
+	entity.DaoConsultation: {
+		explorer.ConsultationVotingStarted.Status: entity.DaoEventVotingStarted,
+		explorer.ConsultationPassed.Status:        entity.DaoEventAccepted,
+		explorer.ConsultationExpired.Status:       entity.DaoEventExpired,
+	},
+}
+
+type Handler func(n network.Network, event *entity.DaoEvent)
+
+type Parameters struct {
+	Type    string `form:"type"`
+	Element string `form:"element"`
+	Hash    string `form:"hash"`
+}
+
+type Service interface {
+	GetEvents(n network.Network, parameters Parameters, pagination framework.Pagination) ([]*entity.DaoEvent, int64, error)
+	OnEvent(handler Handler)
+}
+
+type service struct {
+	proposalRepository       repository.DaoProposalRepository
+	paymentRequestRepository repository.DaoPaymentRequestRepository
+	consultationRepository   repository.DaoConsultationRepository
+	path                     string
+	retention                int
+	states                   map[string]*networkState
+	handlers                 []Handler
+	mu                       sync.RWMutex
+}
+
+// change is the status of an element at the best block
+type change struct {
+	element      entity.DaoElement
+	hash         string
+	proposalHash string
+	description  string
+	status       string
+}
+
+// NewDaoEventService diffs the DAO at each best block published by the watcher against the states kept in the file at path,
+// which keeps the latest retention events of each network
+func NewDaoEventService(
+	proposalRepository repository.DaoProposalRepository,
+	paymentRequestRepository repository.DaoPaymentRequestRepository,
+	consultationRepository repository.DaoConsultationRepository,
+	watcher block.Watcher,
+	path string,
+	retention int,
+) (Service, error) {
+	states, err := loadStates(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &service{
+		proposalRepository:       proposalRepository,
+		paymentRequestRepository: paymentRequestRepository,
+		consultationRepository:   consultationRepository,
+		path:                     path,
+		retention:                retention,
+		states:                   states,
+		handlers:                 make([]Handler, 0),
+	}
+	watcher.OnBlock(s.onBlock)
+
+	return s, nil
+}
+
+// GetEvents returns the events of the network, newest first
+func (s *service) GetEvents(n network.Network, parameters Parameters, pagination framework.Pagination) ([]*entity.DaoEvent, int64, error) {
+	if parameters.Type != "" && !isEventType(parameters.Type) {
+		return nil, 0, ErrDaoEventTypeInvalid
+	}
+	if parameters.Element != "" && !isElement(parameters.Element) {
+		return nil, 0, ErrDaoElementInvalid
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := make([]*entity.DaoEvent, 0)
+	state, ok := s.states[n.Name]
+	if !ok {
+		return events, 0, nil
+	}
+
+	for i := len(state.Events) - 1; i >= 0; i-- {
+		if parameters.matches(state.Events[i]) {
+			events = append(events, state.Events[i])
+		}
+	}
+
+	total := len(events)
+	from := pagination.From()
+	if from > total {
+		from = total
+	}
+	to := from + pagination.Size()
+	if to > total {
+		to = total
+	}
+
+	return events[from:to], int64(total), nil
+}
+
+func (s *service) OnEvent(handler Handler) {
+	s.mu.Lock()
+	s.handlers = append(s.handlers, handler)
+	s.mu.Unlock()
+}
+
+// onBlock records the events of each change since the last best block.
+// The first best block of a network only records the states, so the existing DAO does not appear as new.
+func (s *service) onBlock(n network.Network, block *explorer.Block) {
+	// Elements are only indexed at the best block, so blocks published while the watcher catches up are skipped
+	if !block.Best {
+		return
+	}
+
+	s.mu.RLock()
+	state, seen := s.states[n.Name]
+	var since uint64
+	if seen && state.Height > rescanBlocks {
+		since = state.Height - rescanBlocks
+	}
+	s.mu.RUnlock()
+
+	changes, err := s.getChanges(n, since)
+	if err != nil {
+		zap.L().With(zap.Error(err), zap.String("network", n.String()), zap.Uint64("height", block.Height)).
+			Error("DaoEvent: Failed to get the DAO changes")
+		return
+	}
+
+	s.mu.Lock()
+	if !seen {
+		state = newNetworkState()
+		s.states[n.Name] = state
+	}
+
+	changed := !seen
+	events := make([]*entity.DaoEvent, 0)
+	for _, c := range changes {
+		previous, known := state.States[c.element][c.hash]
+		if known && previous == c.status {
+			continue
+		}
+		state.States[c.element][c.hash] = c.status
+		changed = true
+
+		if !seen {
+			continue
+		}
+		for _, eventType := range eventTypes(c.element, previous, known, c.status) {
+			event := &entity.DaoEvent{
+				Network:        n.Name,
+				Type:           eventType,
+				Element:        c.element,
+				Hash:           c.hash,
+				ProposalHash:   c.proposalHash,
+				Description:    c.description,
+				Status:         c.status,
+				PreviousStatus: previous,
+				Height:         block.Height,
+				Time:           block.Time,
+			}
+			state.add(event, s.retention)
+			events = append(events, event)
+		}
+	}
+	state.Height = block.Height
+
+	if changed {
+		if err := saveStates(s.path, s.states); err != nil {
+			zap.L().With(zap.Error(err), zap.String("path", s.path)).Error("DaoEvent: Failed to save the DAO states")
+		}
+	}
+	handlers := s.handlers
+	s.mu.Unlock()
+
+	if !seen {
+		zap.L().With(zap.String("network", n.String()), zap.Uint64("height", block.Height), zap.Int("elements", len(changes))).
+			Info("DaoEvent: Recorded the DAO states")
+	}
+
+	for _, event := range events {
+		zap.L().With(zap.String("network", n.String()), zap.String("element", string(event.Element)), zap.String("hash", event.Hash), zap.String("type", string(event.Type))).
+			Info("DaoEvent: New event")
+		for _, handler := range handlers {
+			handler(n, event)
+		}
+	}
+}
+
+// getChanges returns the status of every element updated after the height
+func (s *service) getChanges(n network.Network, height uint64) ([]*change, error) {
+	proposals, err := s.proposalRepository.GetProposalsUpdatedSince(n, height)
+	if err != nil {
+		return nil, err
+	}
+	paymentRequests, err := s.paymentRequestRepository.GetPaymentRequestsUpdatedSince(n, height)
+	if err != nil {
+		return nil, err
+	}
+	consultations, err := s.consultationRepository.GetConsultationsUpdatedSince(n, height)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*change, 0, len(proposals)+len(paymentRequests)+len(consultations))
+	for _, p := range proposals {
+		changes = append(changes, &change{
+			element:     entity.DaoProposal,
+			hash:        p.Hash,
+			description: p.Description,
+			status:      p.Status,
+		})
+	}
+	for _, p := range paymentRequests {
+		changes = append(changes, &change{
+			element:      entity.DaoPaymentRequest,
+			hash:         p.Hash,
+			proposalHash: p.ProposalHash,
+			description:  p.Description,
+			status:       p.Status,
+		})
+	}
+	for _, c := range consultations {
+		changes = append(changes, &change{
+			element:     entity.DaoConsultation,
+			hash:        c.Hash,
+			description: c.Question,
+			status:      c.Status,
+		})
+	}
+
+	return changes, nil
+}
+
+// eventTypes are the events of an element entering a status, nothing when the previous status had the same event
+func eventTypes(element entity.DaoElement, previous string, known bool, status string) []entity.DaoEventType {
+	eventTypes := make([]entity.DaoEventType, 0)
+	if !known {
+		eventTypes = append(eventTypes, entity.DaoEventCreated)
+	}
+
+	eventType, ok := statusEvents[element][status]
+	if !ok {
+		return eventTypes
+	}
+	if previousType, ok := statusEvents[element][previous]; known && ok && previousType == eventType {
+		return eventTypes
+	}
+
+	return append(eventTypes, eventType)
+}
+
+func (p Parameters) matches(event *entity.DaoEvent) bool {
+	if p.Type != "" && string(event.Type) != p.Type {
+		return false
+	}
+	if p.Element != "" && string(event.Element) != p.Element {
+		return false
+	}
+	if p.Hash != "" && event.Hash != p.Hash && event.ProposalHash != p.Hash {
+		return false
+	}
+
+	return true
+}
+
+func isEventType(value string) bool {
+	for _, eventType := range entity.DaoEventTypes {
+		if string(eventType) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isElement(value string) bool {
+	for _, element := range entity.DaoElements {
+		if string(element) == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package event
+
+import (
+	"encoding/json"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/entity"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// networkState is the status of every proposal, payment request and consultation at Height, keyed by element and hash,
+// and the most recent events. LastId is the id of the newest event, including those no longer kept.
+type networkState struct {
+	Height uint64                                  `json:"height"`
+	States map[entity.DaoElement]map[string]string `json:"states"`
+	Events []*entity.DaoEvent                      `json:"events"`
+	LastId uint64                                  `json:"last_id"`
+}
+
+func newNetworkState() *networkState {
+	state := &networkState{
+		States: make(map[entity.DaoElement]map[string]string),
+		Events: make([]*entity.DaoEvent, 0),
+	}
+	for _, element := range entity.DaoElements {
+		state.States[element] = make(map[string]string)
+	}
+
+	return state
+}
+
+// add numbers the event after the last one and keeps at most retention events, dropping the oldest
+func (state *networkState) add(event *entity.DaoEvent, retention int) {
+	state.LastId++
+	event.Id = state.LastId
+	state.Events = append(state.Events, event)
+
+	if retention > 0 && len(state.Events) > retention {
+		state.Events = append([]*entity.DaoEvent{}, state.Events[len(state.Events)-retention:]...)
+	}
+}
+
+// loadStates reads the states of each network from the JSON file at path, a missing file has no states
+func loadStates(path string) (map[string]*networkState, error) {
+	states := make(map[string]*networkState)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return states, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, err
+	}
+	for _, state := range states {
+		for _, element := range entity.DaoElements {
+			if state.States[element] == nil {
+				state.States[element] = make(map[string]string)
+			}
+		}
+	}
+
+	return states, nil
+}
+
+// saveStates replaces the file at path with a fully written copy so a restart never reads half the states
+func saveStates(path string, states map[string]*networkState) error {
+	data, err := json.Marshal(states)
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
@@ -18,6 +18,7 @@ type Service interface {
 	GetExcludedVotes(n network.Network, cycle uint) (uint, error)
 
 	GetProposals(n network.Network, parameters ProposalParameters, pagination framework.Pagination) ([]*explorer.Proposal, int64, error)
+	SearchProposals(n network.Network, parameters ProposalParameters, pagination framework.Pagination) ([]*entity.ProposalMatch, int64, error)
 	GetProposal(n network.Network, hash string) (*explorer.Proposal, error)
 	GetVotingCycles(n network.Network, element explorer.ChainHeight, count uint) ([]*entity.VotingCycle, error)
 	GetProposalVotes(n network.Network, hash string) ([]*entity.CfundVote, []*entity.VotingCycle, error)
@@ -30,6 +31,7 @@ type Service interface {
 	GetPaymentRequestTrend(n network.Network, hash string) ([]*entity.CfundTrend, error)
 
 	GetConsultations(n network.Network, parameters ConsultationParameters, pagination framework.Pagination) ([]*explorer.Consultation, int64, error)
+	SearchConsultations(n network.Network, parameters ConsultationParameters, pagination framework.Pagination) ([]*entity.ConsultationMatch, int64, error)
 	GetConsultation(n network.Network, hash string) (*explorer.Consultation, error)
 	GetAnswer(n network.Network, hash string) (*explorer.Answer, error)
 	GetAnswerVotes(n network.Network, consultationHash string, hash string) ([]*entity.CfundVote, []*entity.VotingCycle, error)
@@ -52,11 +54,15 @@ type ConsultationParameters struct {
 	Status    *explorer.ConsultationStatus `form:"-"`
 	Consensus *bool                        `form:"consensus"`
 	Min       *uint                        `form:"min"`
+	Query     string                       `form:"q"`
 }
 
+// ProposalParameters with Newest orders the proposals newest first rather than grouped by state
 type ProposalParameters struct {
-	State *uint `form:"state"`
-	Votes bool  `form:"votes"`
+	State  *uint  `form:"state"`
+	Votes  bool   `form:"votes"`
+	Query  string `form:"q"`
+	Newest bool   `form:"-"`
 }
 
 type PaymentRequestParameters struct {
@@ -145,7 +151,7 @@ func (s *service) GetProposals(n network.Network, parameters ProposalParameters,
 		status = &s
 	}
 
-	proposals, total, err := s.proposalRepository.GetProposals(n, status, false, pagination.Size(), pagination.Page())
+	proposals, total, err := s.proposalRepository.GetProposals(n, status, !parameters.Newest, false, pagination.Size(), pagination.Page())
 	if err == nil {
 		for _, proposal := range proposals {
 			proposal.VotesExcluded = s.getExcludedVotesForProposal(n, *proposal)
@@ -155,6 +161,24 @@ func (s *service) GetProposals(n network.Network, parameters ProposalParameters,
 	return proposals, total, err
 }
 
+// SearchProposals full-text searches the descriptions of proposals in the state, or in any state
+func (s *service) SearchProposals(n network.Network, parameters ProposalParameters, pagination framework.Pagination) ([]*entity.ProposalMatch, int64, error) {
+	var status *explorer.ProposalStatus
+	if parameters.State != nil && explorer.IsProposalStateValid(*parameters.State) {
+		s := explorer.GetProposalStatusByState(*parameters.State)
+		status = &s
+	}
+
+	proposals, total, err := s.proposalRepository.SearchProposals(n, parameters.Query, status, pagination.Size(), pagination.Page())
+	if err == nil {
+		for _, proposal := range proposals {
+			proposal.VotesExcluded = s.getExcludedVotesForProposal(n, *proposal.Proposal)
+		}
+	}
+
+	return proposals, total, err
+}
+
 func (s *service) GetProposal(n network.Network, hash string) (*explorer.Proposal, error) {
 	proposal, err := s.proposalRepository.GetProposal(n, hash)
 	if err == nil {
@@ -376,6 +400,16 @@ func (s *service) GetConsultations(n network.Network, parameters ConsultationPar
 	return s.consultationRepository.GetConsultations(n, parameters.Status, parameters.Consensus, parameters.Min, false, pagination.Size(), pagination.Page())
 }
 
+// SearchConsultations full-text searches the questions and answers of consultations
+func (s *service) SearchConsultations(n network.Network, parameters ConsultationParameters, pagination framework.Pagination) ([]*entity.ConsultationMatch, int64, error) {
+	if parameters.State != nil && explorer.IsConsultationStateValid(*parameters.State) {
+		s := explorer.GetConsultationStatusByState(*parameters.State)
+		parameters.Status = &s
+	}
+
+	return s.consultationRepository.SearchConsultations(n, parameters.Query, parameters.Status, parameters.Consensus, parameters.Min, pagination.Size(), pagination.Page())
+}
+
 func (s *service) GetConsultation(n network.Network, hash string) (*explorer.Consultation, error) {
 	return s.consultationRepository.GetConsultation(n, hash)
 }
@@ -0,0 +1,22 @@
+package entity
+
+type Address struct {
+	Address      string        `json:"address"`
+	ChainStats   *AddressStats `json:"chain_stats"`
+	MempoolStats *AddressStats `json:"mempool_stats"`
+}
+
+type AddressStats struct {
+	FundedTxoCount int64 `json:"funded_txo_count"`
+	FundedTxoSum   int64 `json:"funded_txo_sum"`
+	SpentTxoCount  int64 `json:"spent_txo_count"`
+	SpentTxoSum    int64 `json:"spent_txo_sum"`
+	TxCount        int64 `json:"tx_count"`
+}
+
+type Utxo struct {
+	Txid   string             `json:"txid"`
+	Vout   int                `json:"vout"`
+	Status *TransactionStatus `json:"status"`
+	Value  uint64             `json:"value"`
+}
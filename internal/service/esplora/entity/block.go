@@ -0,0 +1,24 @@
+package entity
+
+type Block struct {
+	Id                string  `json:"id"`
+	Height            uint64  `json:"height"`
+	Version           uint32  `json:"version"`
+	Timestamp         int64   `json:"timestamp"`
+	TxCount           uint    `json:"tx_count"`
+	Size              uint64  `json:"size"`
+	Weight            uint64  `json:"weight"`
+	MerkleRoot        string  `json:"merkle_root"`
+	PreviousBlockHash string  `json:"previousblockhash"`
+	MedianTime        int64   `json:"mediantime"`
+	Nonce             uint64  `json:"nonce"`
+	Bits              string  `json:"bits"`
+	Difficulty        float64 `json:"difficulty"`
+}
+
+// BlockStatus has no next best block at the tip
+type BlockStatus struct {
+	InBestChain bool    `json:"in_best_chain"`
+	Height      uint64  `json:"height"`
+	NextBest    *string `json:"next_best"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+type Transaction struct {
+	Txid     string             `json:"txid"`
+	Version  uint32             `json:"version"`
+	LockTime uint32             `json:"locktime"`
+	Vin      []*Input           `json:"vin"`
+	Vout     []*Output          `json:"vout"`
+	Size     uint64             `json:"size"`
+	Weight   uint64             `json:"weight"`
+	Fee      uint64             `json:"fee"`
+	Status   *TransactionStatus `json:"status"`
+}
+
+// TransactionStatus is always confirmed as only transactions in blocks are indexed
+type TransactionStatus struct {
+	Confirmed   bool   `json:"confirmed"`
+	BlockHeight uint64 `json:"block_height,omitempty"`
+	BlockHash   string `json:"block_hash,omitempty"`
+	BlockTime   int64  `json:"block_time,omitempty"`
+}
+
+// Input has a null prevout when it is a coinbase
+type Input struct {
+	Txid         string   `json:"txid"`
+	Vout         uint32   `json:"vout"`
+	Prevout      *Output  `json:"prevout"`
+	ScriptSig    string   `json:"scriptsig"`
+	ScriptSigAsm string   `json:"scriptsig_asm"`
+	Witness      []string `json:"witness,omitempty"`
+	IsCoinbase   bool     `json:"is_coinbase"`
+	Sequence     uint32   `json:"sequence"`
+}
+
+// Output has an address only when its script pays exactly one
+type Output struct {
+	ScriptPubKey        string `json:"scriptpubkey"`
+	ScriptPubKeyAsm     string `json:"scriptpubkey_asm"`
+	ScriptPubKeyType    string `json:"scriptpubkey_type"`
+	ScriptPubKeyAddress string `json:"scriptpubkey_address,omitempty"`
+	Value               uint64 `json:"value"`
+}
+
+// Outspend only has the spending transaction when Spent
+type Outspend struct {
+	Spent  bool               `json:"spent"`
+	Txid   string             `json:"txid,omitempty"`
+	Vin    *int               `json:"vin,omitempty"`
+	Status *TransactionStatus `json:"status,omitempty"`
+}
@@ -0,0 +1,607 @@
+package esplora
+
+import (
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/esplora/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"strconv"
+	"strings"
+)
+
+const (
+	blocksPageSize            = 10
+	blockTransactionsPageSize = 25
+	chainTransactionsPageSize = 25
+	coinbaseVout              = 4294967295
+)
+
+var coinbaseTxid = strings.Repeat("0", 64)
+
+var (
+	ErrAddressInvalid            = errors.New("Invalid address")
+	ErrStartIndexInvalid         = fmt.Errorf("Start index must be a multiple of %d", blockTransactionsPageSize)
+	ErrLastSeenInvalid           = errors.New("The last seen txid is not a transaction of the address")
+	ErrTransactionNotFound       = errors.New("Transaction not found")
+	ErrOutputNotFound            = errors.New("Output not found")
+	ErrRawTransactionUnavailable = errors.New("Raw transaction is not available")
+)
+
+func IsEsploraError(err error) bool {
+	return err == ErrAddressInvalid || err == ErrStartIndexInvalid || err == ErrLastSeenInvalid
+}
+
+// scriptTypes are the Esplora names of the standard output types, other NavCoin types keep their own name
+var scriptTypes = map[explorer.VoutType]string{
+	explorer.VoutPubkey:      "p2pk",
+	explorer.VoutPubkeyhash:  "p2pkh",
+	explorer.VoutScripthash:  "p2sh",
+	explorer.VoutMultiSig:    "multisig",
+	explorer.VoutNulldata:    "op_return",
+	explorer.VoutNonstandard: "unknown",
+}
+
+// Service answers in the response shapes of the Esplora API so tools built for it can use the explorer.
+// Only indexed blocks are known, so every transaction is confirmed and the mempool is empty.
+type Service interface {
+	GetTipHeight(n network.Network) (uint64, error)
+	GetTipHash(n network.Network) (string, error)
+	GetBlockHash(n network.Network, height uint64) (string, error)
+	GetBlocks(n network.Network, startHeight *uint64) ([]*entity.Block, error)
+	GetBlock(n network.Network, hash string) (*entity.Block, error)
+	GetBlockStatus(n network.Network, hash string) (*entity.BlockStatus, error)
+	GetBlockTxids(n network.Network, hash string) ([]string, error)
+	GetBlockTxid(n network.Network, hash string, index int) (string, error)
+	GetBlockTransactions(n network.Network, hash string, startIndex int) ([]*entity.Transaction, error)
+	GetTransaction(n network.Network, txid string) (*entity.Transaction, error)
+	GetTransactionStatus(n network.Network, txid string) (*entity.TransactionStatus, error)
+	GetTransactionHex(n network.Network, txid string) (string, error)
+	GetOutspends(n network.Network, txid string) ([]*entity.Outspend, error)
+	GetOutspend(n network.Network, txid string, vout int) (*entity.Outspend, error)
+	GetAddress(n network.Network, hash string) (*entity.Address, error)
+	GetAddressTransactions(n network.Network, hash string, lastSeen string) ([]*entity.Transaction, error)
+	GetUnspentOutputs(n network.Network, hash string) ([]*entity.Utxo, error)
+}
+
+type service struct {
+	addressService             address.Service
+	blockService               block.Service
+	addressHistoryRepository   repository.AddressHistoryRepository
+	blockTransactionRepository repository.BlockTransactionRepository
+}
+
+func NewEsploraService(
+	addressService address.Service,
+	blockService block.Service,
+	addressHistoryRepository repository.AddressHistoryRepository,
+	blockTransactionRepository repository.BlockTransactionRepository,
+) Service {
+	return &service{addressService, blockService, addressHistoryRepository, blockTransactionRepository}
+}
+
+func (s *service) GetTipHeight(n network.Network) (uint64, error) {
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return bestBlock.Height, nil
+}
+
+func (s *service) GetTipHash(n network.Network) (string, error) {
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return "", err
+	}
+
+	return bestBlock.Hash, nil
+}
+
+func (s *service) GetBlockHash(n network.Network, height uint64) (string, error) {
+	b, err := s.blockService.GetBlock(n, strconv.FormatUint(height, 10))
+	if err != nil {
+		return "", err
+	}
+	if b.Height != height {
+		return "", repository.ErrBlockNotFound
+	}
+
+	return b.Hash, nil
+}
+
+// GetBlocks returns ten blocks down from the start height, or from the tip when no height is given
+func (s *service) GetBlocks(n network.Network, startHeight *uint64) ([]*entity.Block, error) {
+	options := framework.FilterOptions{}
+	if startHeight != nil {
+		options = append(options, framework.NewFilterExpression("height", framework.FilterLessThanOrEqual, false, []interface{}{*startHeight}))
+	}
+
+	request := framework.NewRestRequest(n, framework.NewPagination(1, blocksPageSize), framework.NewFilters(options), framework.NewSort(nil))
+	blocks, _, err := s.blockService.GetBlocks(n, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Block, 0, len(blocks))
+	for _, b := range blocks {
+		result = append(result, esploraBlock(b))
+	}
+
+	return result, nil
+}
+
+func (s *service) GetBlock(n network.Network, hash string) (*entity.Block, error) {
+	b, err := s.getBlock(n, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return esploraBlock(b), nil
+}
+
+func (s *service) GetBlockStatus(n network.Network, hash string) (*entity.BlockStatus, error) {
+	b, err := s.getBlock(n, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &entity.BlockStatus{InBestChain: true, Height: b.Height}
+	if b.Nextblockhash != "" {
+		status.NextBest = &b.Nextblockhash
+	}
+
+	return status, nil
+}
+
+func (s *service) GetBlockTxids(n network.Network, hash string) ([]string, error) {
+	b, err := s.getBlock(n, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Tx, nil
+}
+
+func (s *service) GetBlockTxid(n network.Network, hash string, index int) (string, error) {
+	txids, err := s.GetBlockTxids(n, hash)
+	if err != nil {
+		return "", err
+	}
+	if index < 0 || index >= len(txids) {
+		return "", ErrTransactionNotFound
+	}
+
+	return txids[index], nil
+}
+
+// GetBlockTransactions returns 25 transactions of the block from the start index
+func (s *service) GetBlockTransactions(n network.Network, hash string, startIndex int) ([]*entity.Transaction, error) {
+	if startIndex < 0 || startIndex%blockTransactionsPageSize != 0 {
+		return nil, ErrStartIndexInvalid
+	}
+	if _, err := s.getBlock(n, hash); err != nil {
+		return nil, err
+	}
+
+	txs, err := s.blockService.GetTransactionsByBlockHash(n, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if startIndex > len(txs) {
+		startIndex = len(txs)
+	}
+	end := startIndex + blockTransactionsPageSize
+	if end > len(txs) {
+		end = len(txs)
+	}
+
+	return s.transactions(n, txs[startIndex:end])
+}
+
+func (s *service) GetTransaction(n network.Network, txid string) (*entity.Transaction, error) {
+	tx, err := s.getTransaction(n, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := s.transactions(n, []*explorer.BlockTransaction{tx})
+	if err != nil {
+		return nil, err
+	}
+
+	return txs[0], nil
+}
+
+func (s *service) GetTransactionStatus(n network.Network, txid string) (*entity.TransactionStatus, error) {
+	tx, err := s.getTransaction(n, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	return status(tx), nil
+}
+
+func (s *service) GetTransactionHex(n network.Network, txid string) (string, error) {
+	tx, err := s.blockService.GetRawTransactionByHash(n, txid)
+	if err == repository.ErrBlockNotFound {
+		return "", ErrTransactionNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if tx.Hex == "" {
+		return "", ErrRawTransactionUnavailable
+	}
+
+	return tx.Hex, nil
+}
+
+// GetOutspends returns whether each output of the transaction is spent, and the input spending it
+func (s *service) GetOutspends(n network.Network, txid string) ([]*entity.Outspend, error) {
+	tx, err := s.getTransaction(n, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	spenderTxids := make([]string, 0)
+	for _, vout := range tx.Vout {
+		if vout.Redeemed && vout.RedeemedIn != nil {
+			spenderTxids = append(spenderTxids, vout.RedeemedIn.Hash)
+		}
+	}
+	spenders, err := s.getTransactions(n, spenderTxids)
+	if err != nil {
+		return nil, err
+	}
+
+	outspends := make([]*entity.Outspend, 0, len(tx.Vout))
+	for _, vout := range tx.Vout {
+		if !vout.Redeemed || vout.RedeemedIn == nil {
+			outspends = append(outspends, &entity.Outspend{Spent: false})
+			continue
+		}
+
+		outspend := &entity.Outspend{
+			Spent:  true,
+			Txid:   vout.RedeemedIn.Hash,
+			Status: &entity.TransactionStatus{Confirmed: true, BlockHeight: vout.RedeemedIn.Height},
+		}
+		if spender, ok := spenders[vout.RedeemedIn.Hash]; ok {
+			outspend.Status = status(spender)
+			for i, vin := range spender.Vin {
+				if vin.Txid != nil && *vin.Txid == tx.Txid && vin.Vout != nil && *vin.Vout == vout.N {
+					index := i
+					outspend.Vin = &index
+					break
+				}
+			}
+		}
+		outspends = append(outspends, outspend)
+	}
+
+	return outspends, nil
+}
+
+func (s *service) GetOutspend(n network.Network, txid string, vout int) (*entity.Outspend, error) {
+	outspends, err := s.GetOutspends(n, txid)
+	if err != nil {
+		return nil, err
+	}
+	if vout < 0 || vout >= len(outspends) {
+		return nil, ErrOutputNotFound
+	}
+
+	return outspends[vout], nil
+}
+
+// GetAddress sums the outputs paying the address and those spent, an address without history has empty stats
+func (s *service) GetAddress(n network.Network, hash string) (*entity.Address, error) {
+	if err := s.validateAddress(n, hash); err != nil {
+		return nil, err
+	}
+
+	fundedCount, fundedSum, spentCount, spentSum, err := s.blockTransactionRepository.GetOutputSummary(n, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	txCount, err := s.addressHistoryRepository.GetCountByHash(n, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Address{
+		Address: hash,
+		ChainStats: &entity.AddressStats{
+			FundedTxoCount: fundedCount,
+			FundedTxoSum:   fundedSum,
+			SpentTxoCount:  spentCount,
+			SpentTxoSum:    spentSum,
+			TxCount:        txCount,
+		},
+		MempoolStats: &entity.AddressStats{},
+	}, nil
+}
+
+// GetAddressTransactions returns 25 transactions of the address newest first.
+// Given the last seen txid of a previous page it returns the 25 transactions after it.
+func (s *service) GetAddressTransactions(n network.Network, hash string, lastSeen string) ([]*entity.Transaction, error) {
+	if err := s.validateAddress(n, hash); err != nil {
+		return nil, err
+	}
+
+	pagination := framework.NewPagination(1, chainTransactionsPageSize)
+	if lastSeen != "" {
+		history, err := s.addressHistoryRepository.GetByHashAndTxid(n, hash, lastSeen)
+		if err == repository.ErrAddressHistoryNotFound {
+			return nil, ErrLastSeenInvalid
+		}
+		if err != nil {
+			return nil, err
+		}
+		// The history is sorted by height and then txindex, so the entry's values are a cursor after it
+		pagination = framework.NewCursorPagination(chainTransactionsPageSize, &framework.Cursor{Values: []interface{}{history.Height, history.TxIndex}})
+	}
+
+	history, _, err := s.addressHistoryRepository.GetHistoryByHash(n, hash, pagination, framework.NewSort(nil), framework.NewFilters(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	txids := make([]string, 0, len(history))
+	for _, h := range history {
+		txids = append(txids, h.TxId)
+	}
+	found, err := s.getTransactions(n, txids)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*explorer.BlockTransaction, 0, len(txids))
+	for _, txid := range txids {
+		if tx, ok := found[txid]; ok {
+			txs = append(txs, tx)
+		}
+	}
+
+	return s.transactions(n, txs)
+}
+
+func (s *service) GetUnspentOutputs(n network.Network, hash string) ([]*entity.Utxo, error) {
+	if err := s.validateAddress(n, hash); err != nil {
+		return nil, err
+	}
+
+	outputs, err := s.addressService.GetUnspentOutputs(n, []string{hash})
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]*entity.Utxo, 0, len(outputs))
+	for _, o := range outputs {
+		utxos = append(utxos, &entity.Utxo{
+			Txid: o.Txid,
+			Vout: o.Vout,
+			Status: &entity.TransactionStatus{
+				Confirmed:   true,
+				BlockHeight: o.Height,
+				BlockHash:   o.BlockHash,
+				BlockTime:   o.Time.Unix(),
+			},
+			Value: o.Value,
+		})
+	}
+
+	return utxos, nil
+}
+
+// getBlock only finds blocks by hash, the block service would also take a height
+func (s *service) getBlock(n network.Network, hash string) (*explorer.Block, error) {
+	b, err := s.blockService.GetBlock(n, hash)
+	if err != nil {
+		return nil, err
+	}
+	if b.Hash != hash {
+		return nil, repository.ErrBlockNotFound
+	}
+
+	return b, nil
+}
+
+func (s *service) getTransaction(n network.Network, txid string) (*explorer.BlockTransaction, error) {
+	tx, err := s.blockService.GetTransactionByHash(n, txid)
+	if err == repository.ErrBlockNotFound {
+		return nil, ErrTransactionNotFound
+	}
+
+	return tx, err
+}
+
+// getTransactions returns the transactions of the txids keyed by txid
+func (s *service) getTransactions(n network.Network, txids []string) (map[string]*explorer.BlockTransaction, error) {
+	unique := make([]string, 0, len(txids))
+	seen := make(map[string]bool)
+	for _, txid := range txids {
+		if !seen[txid] {
+			seen[txid] = true
+			unique = append(unique, txid)
+		}
+	}
+
+	txs, err := s.blockService.GetTransactionsByTxids(n, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]*explorer.BlockTransaction)
+	for _, tx := range txs {
+		found[tx.Txid] = tx
+	}
+
+	return found, nil
+}
+
+// transactions maps the transactions with the outputs their inputs spend
+func (s *service) transactions(n network.Network, txs []*explorer.BlockTransaction) ([]*entity.Transaction, error) {
+	prevTxids := make([]string, 0)
+	for _, tx := range txs {
+		for _, vin := range tx.Vin {
+			if !vin.IsCoinbase() && vin.Txid != nil {
+				prevTxids = append(prevTxids, *vin.Txid)
+			}
+		}
+	}
+
+	prevTxs, err := s.getTransactions(n, prevTxids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*entity.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		result = append(result, transaction(tx, prevTxs))
+	}
+
+	return result, nil
+}
+
+func (s *service) validateAddress(n network.Network, hash string) error {
+	validation, err := s.addressService.ValidateAddress(n, hash)
+	if err != nil {
+		return err
+	}
+	if !validation.Valid {
+		return ErrAddressInvalid
+	}
+
+	return nil
+}
+
+func transaction(tx *explorer.BlockTransaction, prevTxs map[string]*explorer.BlockTransaction) *entity.Transaction {
+	t := &entity.Transaction{
+		Txid:     tx.Txid,
+		Version:  tx.Version,
+		LockTime: tx.LockTime,
+		Vin:      make([]*entity.Input, 0, len(tx.Vin)),
+		Vout:     make([]*entity.Output, 0, len(tx.Vout)),
+		Size:     tx.Size,
+		Weight:   tx.VSize * 4,
+		Fee:      tx.Fees,
+		Status:   status(tx),
+	}
+
+	for _, vin := range tx.Vin {
+		if vin.IsCoinbase() {
+			t.Vin = append(t.Vin, &entity.Input{
+				Txid:       coinbaseTxid,
+				Vout:       coinbaseVout,
+				ScriptSig:  vin.Coinbase,
+				IsCoinbase: true,
+				Sequence:   vin.Sequence,
+			})
+			continue
+		}
+
+		input := &entity.Input{Sequence: vin.Sequence, Prevout: prevout(vin, prevTxs)}
+		if vin.Txid != nil {
+			input.Txid = *vin.Txid
+		}
+		if vin.Vout != nil {
+			input.Vout = uint32(*vin.Vout)
+		}
+		if vin.ScriptSig != nil {
+			input.ScriptSig = vin.ScriptSig.Hex
+			input.ScriptSigAsm = vin.ScriptSig.Asm
+		}
+		t.Vin = append(t.Vin, input)
+	}
+
+	for _, vout := range tx.Vout {
+		t.Vout = append(t.Vout, output(vout))
+	}
+
+	return t
+}
+
+// prevout is the output an input spends, or what the input records of it when the transaction is not found
+func prevout(vin explorer.Vin, prevTxs map[string]*explorer.BlockTransaction) *entity.Output {
+	if vin.Txid != nil && vin.Vout != nil {
+		if prevTx, ok := prevTxs[*vin.Txid]; ok {
+			for _, vout := range prevTx.Vout {
+				if vout.N == *vin.Vout {
+					return output(vout)
+				}
+			}
+		}
+	}
+
+	o := &entity.Output{Value: vin.ValueSat}
+	if vin.PreviousOutput != nil {
+		o.ScriptPubKeyType = scriptType(vin.PreviousOutput.Type)
+	}
+	if len(vin.Addresses) == 1 {
+		o.ScriptPubKeyAddress = vin.Addresses[0]
+	}
+
+	return o
+}
+
+func output(vout explorer.Vout) *entity.Output {
+	o := &entity.Output{
+		ScriptPubKey:     vout.ScriptPubKey.Hex,
+		ScriptPubKeyAsm:  vout.ScriptPubKey.Asm,
+		ScriptPubKeyType: scriptType(vout.ScriptPubKey.Type),
+		Value:            vout.ValueSat,
+	}
+	if vout.ScriptPubKey.Hex == "" {
+		o.ScriptPubKeyType = "empty"
+	}
+	if len(vout.ScriptPubKey.Addresses) == 1 {
+		o.ScriptPubKeyAddress = vout.ScriptPubKey.Addresses[0]
+	}
+
+	return o
+}
+
+func status(tx *explorer.BlockTransaction) *entity.TransactionStatus {
+	return &entity.TransactionStatus{
+		Confirmed:   true,
+		BlockHeight: tx.Height,
+		BlockHash:   tx.BlockHash,
+		BlockTime:   tx.BlockTime.Unix(),
+	}
+}
+
+func esploraBlock(b *explorer.Block) *entity.Block {
+	difficulty, _ := strconv.ParseFloat(b.Difficulty, 64)
+
+	return &entity.Block{
+		Id:                b.Hash,
+		Height:            b.Height,
+		Version:           b.Version,
+		Timestamp:         b.Time.Unix(),
+		TxCount:           b.TxCount,
+		Size:              b.Size,
+		Weight:            b.Weight,
+		MerkleRoot:        b.Merkleroot,
+		PreviousBlockHash: b.Previousblockhash,
+		MedianTime:        b.MedianTime.Unix(),
+		Nonce:             b.Nonce,
+		Bits:              b.Bits,
+		Difficulty:        difficulty,
+	}
+}
+
+func scriptType(voutType explorer.VoutType) string {
+	if name, ok := scriptTypes[voutType]; ok {
+		return name
+	}
+
+	return string(voutType)
+}
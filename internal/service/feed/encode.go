@@ -0,0 +1,170 @@
+package feed
+
+import (
+	"encoding/xml"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/feed/entity"
+	"time"
+)
+
+type Format string
+
+var (
+	FormatAtom Format = "atom"
+	FormatRss  Format = "rss"
+)
+
+func (f Format) ContentType() string {
+	if f == FormatRss {
+		return "application/rss+xml; charset=utf-8"
+	}
+
+	return "application/atom+xml; charset=utf-8"
+}
+
+func IsFormatValid(format string) bool {
+	return Format(format) == FormatAtom || Format(format) == FormatRss
+}
+
+type atomFeed struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	Id      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []atomLink   `xml:"link"`
+	Author  atomAuthor   `xml:"author"`
+	Entries []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Id        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+	Summary   string   `xml:"summary"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate"`
+	Self          rssSelf    `xml:"atom:link"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Guid        rssGuid `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// Encode renders the feed in the format, resolving its links against base and linking to itself at self
+func Encode(feed *entity.Feed, format Format, base string, self string) ([]byte, error) {
+	var document interface{}
+	if format == FormatRss {
+		document = rss(feed, base, self)
+	} else {
+		document = atom(feed, base, self)
+	}
+
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func atom(feed *entity.Feed, base string, self string) *atomFeed {
+	document := &atomFeed{
+		Id:      feed.Id,
+		Title:   feed.Title,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: FormatAtom.mediaType(), Href: self},
+			{Rel: "alternate", Type: "application/json", Href: base + feed.Link},
+		},
+		Author:  atomAuthor{Name: "NavExplorer"},
+		Entries: make([]*atomEntry, 0, len(feed.Entries)),
+	}
+
+	for _, entry := range feed.Entries {
+		document.Entries = append(document.Entries, &atomEntry{
+			Id:        entry.Id,
+			Title:     entry.Title,
+			Link:      atomLink{Rel: "alternate", Type: "application/json", Href: base + entry.Link},
+			Published: entry.Published.UTC().Format(time.RFC3339),
+			Updated:   entry.Updated.UTC().Format(time.RFC3339),
+			Summary:   entry.Summary,
+		})
+	}
+
+	return document
+}
+
+// rss dates items by when they were published, as RSS has no updated time for an item
+func rss(feed *entity.Feed, base string, self string) *rssFeed {
+	document := &rssFeed{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          base + feed.Link,
+			Description:   feed.Title,
+			LastBuildDate: feed.Updated.UTC().Format(time.RFC1123Z),
+			Self:          rssSelf{Href: self, Rel: "self", Type: FormatRss.mediaType()},
+			Items:         make([]*rssItem, 0, len(feed.Entries)),
+		},
+	}
+
+	for _, entry := range feed.Entries {
+		document.Channel.Items = append(document.Channel.Items, &rssItem{
+			Title:       entry.Title,
+			Link:        base + entry.Link,
+			Guid:        rssGuid{IsPermaLink: false, Value: entry.Id},
+			PubDate:     entry.Published.UTC().Format(time.RFC1123Z),
+			Description: entry.Summary,
+		})
+	}
+
+	return document
+}
+
+func (f Format) mediaType() string {
+	if f == FormatRss {
+		return "application/rss+xml"
+	}
+
+	return "application/atom+xml"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+)
+
+// Feed is a list of entries, newest first, that is rendered as Atom or RSS.
+// Links are paths of the API, resolved against the url the feed is served from.
+type Feed struct {
+	Id      string
+	Title   string
+	Link    string
+	Updated time.Time
+	Entries []*Entry
+}
+
+type Entry struct {
+	Id        string
+	Title     string
+	Link      string
+	Summary   string
+	Published time.Time
+	Updated   time.Time
+}
@@ -0,0 +1,280 @@
+package feed
+
+import (
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/dao"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/feed/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultFeedLimit = 20
+	MaxFeedLimit     = 100
+	// Titles are cut to this many characters of a description or question
+	maxTitleLength = 80
+)
+
+var ErrFeedLimitInvalid = fmt.Errorf("Limit must be between 1 and %d", MaxFeedLimit)
+
+func IsFeedError(err error) bool {
+	return err == ErrFeedLimitInvalid
+}
+
+type Service interface {
+	GetBlocks(n network.Network, limit int) (*entity.Feed, error)
+	GetProposals(n network.Network, limit int) (*entity.Feed, error)
+	GetPaymentRequests(n network.Network, limit int) (*entity.Feed, error)
+	GetConsultations(n network.Network, limit int) (*entity.Feed, error)
+}
+
+type service struct {
+	blockService    block.Service
+	daoService      dao.Service
+	blockRepository repository.BlockRepository
+}
+
+func NewFeedService(blockService block.Service, daoService dao.Service, blockRepository repository.BlockRepository) Service {
+	return &service{blockService, daoService, blockRepository}
+}
+
+func (s *service) GetBlocks(n network.Network, limit int) (*entity.Feed, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	request := framework.NewRestRequest(n, framework.NewPagination(1, limit), framework.NewFilters(nil), framework.NewSort(nil))
+	blocks, _, err := s.blockService.GetBlocks(n, request)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := newFeed(n, "blocks", "Blocks", link(n, "/block"))
+	for _, b := range blocks {
+		summary := fmt.Sprintf("%d transactions, %d bytes", b.TxCount, b.Size)
+		if b.StakedBy != "" {
+			summary += fmt.Sprintf(", staked by %s for %s NAV", b.StakedBy, nav(b.Stake))
+		}
+		if b.Fees != 0 {
+			summary += fmt.Sprintf(", %s NAV fees", nav(b.Fees))
+		}
+
+		feed.Entries = append(feed.Entries, &entity.Entry{
+			Id:        entryId(n, "block", b.Hash),
+			Title:     fmt.Sprintf("Block %d", b.Height),
+			Link:      link(n, "/block/"+b.Hash),
+			Summary:   summary,
+			Published: b.Time,
+			Updated:   b.Time,
+		})
+	}
+
+	return s.finish(n, feed)
+}
+
+func (s *service) GetProposals(n network.Network, limit int) (*entity.Feed, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	proposals, _, err := s.daoService.GetProposals(n, dao.ProposalParameters{Newest: true}, framework.NewPagination(1, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]uint64, 0)
+	for _, p := range proposals {
+		heights = append(heights, p.Height, p.UpdatedOnBlock)
+	}
+	times, err := s.blockRepository.GetTimesAtHeights(n, heights)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := newFeed(n, "proposals", "Proposals", link(n, "/dao/cfund/proposal"))
+	for _, p := range proposals {
+		published, updated := entryTimes(times, p.Height, p.UpdatedOnBlock)
+		feed.Entries = append(feed.Entries, &entity.Entry{
+			Id:    entryId(n, "proposal", p.Hash),
+			Title: "Proposal: " + title(p.Description),
+			Link:  link(n, "/dao/cfund/proposal/"+p.Hash),
+			Summary: fmt.Sprintf(
+				"%s\n\nRequests %s NAV over %d days. Status %s with %d yes, %d no and %d abstain votes.",
+				p.Description, amount(p.RequestedAmount), p.ProposalDuration/86400, p.Status, p.VotesYes, p.VotesNo, p.VotesAbs,
+			),
+			Published: published,
+			Updated:   updated,
+		})
+	}
+
+	return s.finish(n, feed)
+}
+
+func (s *service) GetPaymentRequests(n network.Network, limit int) (*entity.Feed, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	paymentRequests, _, err := s.daoService.GetPaymentRequests(n, dao.PaymentRequestParameters{}, framework.NewPagination(1, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]uint64, 0)
+	for _, p := range paymentRequests {
+		heights = append(heights, p.Height, p.UpdatedOnBlock)
+	}
+	times, err := s.blockRepository.GetTimesAtHeights(n, heights)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := newFeed(n, "payment-requests", "Payment requests", link(n, "/dao/cfund/payment-request"))
+	for _, p := range paymentRequests {
+		published, updated := entryTimes(times, p.Height, p.UpdatedOnBlock)
+		feed.Entries = append(feed.Entries, &entity.Entry{
+			Id:    entryId(n, "payment-request", p.Hash),
+			Title: "Payment request: " + title(p.Description),
+			Link:  link(n, "/dao/cfund/payment-request/"+p.Hash),
+			Summary: fmt.Sprintf(
+				"%s\n\nRequests %s NAV from proposal %s. Status %s with %d yes, %d no and %d abstain votes.",
+				p.Description, amount(p.RequestedAmount), p.ProposalHash, p.Status, p.VotesYes, p.VotesNo, p.VotesAbs,
+			),
+			Published: published,
+			Updated:   updated,
+		})
+	}
+
+	return s.finish(n, feed)
+}
+
+func (s *service) GetConsultations(n network.Network, limit int) (*entity.Feed, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	consultations, _, err := s.daoService.GetConsultations(n, dao.ConsultationParameters{}, framework.NewPagination(1, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]uint64, 0)
+	for _, c := range consultations {
+		heights = append(heights, c.Height, c.UpdatedOnBlock)
+	}
+	times, err := s.blockRepository.GetTimesAtHeights(n, heights)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := newFeed(n, "consultations", "Consultations", link(n, "/dao/consultation"))
+	for _, c := range consultations {
+		published, updated := entryTimes(times, c.Height, c.UpdatedOnBlock)
+		feed.Entries = append(feed.Entries, &entity.Entry{
+			Id:        entryId(n, "consultation", c.Hash),
+			Title:     "Consultation: " + title(c.Question),
+			Link:      link(n, "/dao/consultation/"+c.Hash),
+			Summary:   fmt.Sprintf("%s\n\n%s. Status %s.", c.Question, answers(c), c.Status),
+			Published: published,
+			Updated:   updated,
+		})
+	}
+
+	return s.finish(n, feed)
+}
+
+// finish sets the feed as updated when its latest entry was, or at the best block when it has no entries
+func (s *service) finish(n network.Network, feed *entity.Feed) (*entity.Feed, error) {
+	for _, entry := range feed.Entries {
+		if entry.Updated.After(feed.Updated) {
+			feed.Updated = entry.Updated
+		}
+	}
+
+	if len(feed.Entries) == 0 {
+		bestBlock, err := s.blockService.GetBestBlock(n)
+		if err != nil {
+			return nil, err
+		}
+		feed.Updated = bestBlock.Time
+	}
+
+	return feed, nil
+}
+
+func newFeed(n network.Network, name string, title string, link string) *entity.Feed {
+	return &entity.Feed{
+		Id:      fmt.Sprintf("urn:navexplorer:%s:%s", n.Name, name),
+		Title:   fmt.Sprintf("NavExplorer %s %s", n.Name, strings.ToLower(title)),
+		Link:    link,
+		Entries: make([]*entity.Entry, 0),
+	}
+}
+
+// link is the path of a JSON resource on the network, which is given as a query parameter as feed readers cannot set headers
+func link(n network.Network, path string) string {
+	return path + "?network=" + url.QueryEscape(n.Name)
+}
+
+func entryId(n network.Network, element string, hash string) string {
+	return fmt.Sprintf("urn:navexplorer:%s:%s:%s", n.Name, element, hash)
+}
+
+// entryTimes are the times of the blocks an element was created and last updated in
+func entryTimes(times map[uint64]time.Time, height uint64, updatedOnBlock uint64) (time.Time, time.Time) {
+	published := times[height]
+	updated, ok := times[updatedOnBlock]
+	if !ok || updated.Before(published) {
+		updated = published
+	}
+
+	return published, updated
+}
+
+func answers(c *explorer.Consultation) string {
+	if c.AnswerIsARange {
+		return fmt.Sprintf("Answers range from %d to %d", c.Min, c.Max)
+	}
+
+	values := make([]string, 0, len(c.Answers))
+	for _, a := range c.Answers {
+		values = append(values, a.Answer)
+	}
+	if len(values) == 0 {
+		return "No answers yet"
+	}
+
+	return "Answers: " + strings.Join(values, ", ")
+}
+
+func title(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if runes := []rune(value); len(runes) > maxTitleLength {
+		return string(runes[:maxTitleLength-1]) + "…"
+	}
+
+	return value
+}
+
+func nav(satoshi uint64) string {
+	return amount(float64(satoshi) / 100000000)
+}
+
+func amount(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func validateLimit(limit int) error {
+	if limit < 1 || limit > MaxFeedLimit {
+		return ErrFeedLimitInvalid
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+type Status string
+
+var (
+	StatusOk   Status = "ok"
+	StatusFail Status = "fail"
+)
+
+type Health struct {
+	Status        Status                    `json:"status"`
+	Elasticsearch *ElasticsearchHealth      `json:"elasticsearch,omitempty"`
+	Networks      map[string]*NetworkHealth `json:"networks,omitempty"`
+}
+
+type ElasticsearchHealth struct {
+	Status        Status `json:"status"`
+	ClusterStatus string `json:"cluster_status,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type NetworkHealth struct {
+	Status         Status     `json:"status"`
+	Index          string     `json:"index"`
+	MissingIndices []string   `json:"missing_indices,omitempty"`
+	BestBlock      uint64     `json:"best_block,omitempty"`
+	BestBlockTime  *time.Time `json:"best_block_time,omitempty"`
+	BestBlockAge   float64    `json:"best_block_age,omitempty"`
+	Errors         []string   `json:"errors,omitempty"`
+}
@@ -0,0 +1,101 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/health/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"time"
+)
+
+type Service interface {
+	Live() *entity.Health
+	Ready(ctx context.Context) *entity.Health
+}
+
+type service struct {
+	healthRepo  repository.HealthRepository
+	maxBlockAge time.Duration
+	timeout     time.Duration
+}
+
+func NewHealthService(healthRepo repository.HealthRepository, maxBlockAge, timeout time.Duration) Service {
+	return &service{healthRepo, maxBlockAge, timeout}
+}
+
+// Live reports the process is serving requests, it does not depend on Elasticsearch
+func (s *service) Live() *entity.Health {
+	return &entity.Health{Status: entity.StatusOk}
+}
+
+// Ready checks Elasticsearch is available, each network has its indices and the best block of each network is recent.
+// The checks stop when the request is cancelled.
+func (s *service) Ready(ctx context.Context) *entity.Health {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	health := &entity.Health{
+		Status:        entity.StatusOk,
+		Elasticsearch: s.checkElasticsearch(ctx),
+		Networks:      make(map[string]*entity.NetworkHealth),
+	}
+	if health.Elasticsearch.Status != entity.StatusOk {
+		health.Status = entity.StatusFail
+	}
+
+	for _, n := range network.GetNetworks() {
+		networkHealth := s.checkNetwork(ctx, n)
+		if networkHealth.Status != entity.StatusOk {
+			health.Status = entity.StatusFail
+		}
+		health.Networks[n.Name] = networkHealth
+	}
+
+	return health
+}
+
+func (s *service) checkElasticsearch(ctx context.Context) *entity.ElasticsearchHealth {
+	status, err := s.healthRepo.ClusterStatus(ctx)
+	if err != nil {
+		return &entity.ElasticsearchHealth{Status: entity.StatusFail, Error: err.Error()}
+	}
+
+	health := &entity.ElasticsearchHealth{Status: entity.StatusOk, ClusterStatus: status}
+	if status == "red" {
+		health.Status = entity.StatusFail
+	}
+
+	return health
+}
+
+func (s *service) checkNetwork(ctx context.Context, n network.Network) *entity.NetworkHealth {
+	health := &entity.NetworkHealth{Status: entity.StatusOk, Index: n.Index}
+
+	missing, err := s.healthRepo.MissingIndices(ctx, n)
+	if err != nil {
+		health.Errors = append(health.Errors, err.Error())
+	} else if len(missing) != 0 {
+		health.MissingIndices = missing
+		health.Errors = append(health.Errors, "Indices are missing")
+	}
+
+	bestBlock, err := s.healthRepo.BestBlock(ctx, n)
+	if err != nil {
+		health.Errors = append(health.Errors, err.Error())
+	} else {
+		age := time.Since(bestBlock.Time)
+		health.BestBlock = bestBlock.Height
+		health.BestBlockTime = &bestBlock.Time
+		health.BestBlockAge = age.Seconds()
+		if age > s.maxBlockAge {
+			health.Errors = append(health.Errors, fmt.Sprintf("Best block is older than %s", s.maxBlockAge))
+		}
+	}
+
+	if len(health.Errors) != 0 {
+		health.Status = entity.StatusFail
+	}
+
+	return health
+}
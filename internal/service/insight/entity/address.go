@@ -0,0 +1,29 @@
+package entity
+
+// Address keeps the Insight spelling of txApperances. Nothing is unconfirmed as only blocks are indexed.
+type Address struct {
+	AddrStr                 string   `json:"addrStr"`
+	Balance                 float64  `json:"balance"`
+	BalanceSat              int64    `json:"balanceSat"`
+	TotalReceived           float64  `json:"totalReceived"`
+	TotalReceivedSat        int64    `json:"totalReceivedSat"`
+	TotalSent               float64  `json:"totalSent"`
+	TotalSentSat            int64    `json:"totalSentSat"`
+	UnconfirmedBalance      float64  `json:"unconfirmedBalance"`
+	UnconfirmedBalanceSat   int64    `json:"unconfirmedBalanceSat"`
+	UnconfirmedTxApperances int64    `json:"unconfirmedTxApperances"`
+	TxApperances            int64    `json:"txApperances"`
+	Transactions            []string `json:"transactions,omitempty"`
+}
+
+type Utxo struct {
+	Address       string  `json:"address"`
+	Txid          string  `json:"txid"`
+	Vout          int     `json:"vout"`
+	ScriptPubKey  string  `json:"scriptPubKey"`
+	Amount        float64 `json:"amount"`
+	Satoshis      uint64  `json:"satoshis"`
+	Height        uint64  `json:"height"`
+	Confirmations uint64  `json:"confirmations"`
+	Ts            int64   `json:"ts"`
+}
@@ -0,0 +1,53 @@
+package entity
+
+type PoolInfo struct{}
+
+type Block struct {
+	Hash              string   `json:"hash"`
+	Size              uint64   `json:"size"`
+	Height            uint64   `json:"height"`
+	Version           uint32   `json:"version"`
+	MerkleRoot        string   `json:"merkleroot"`
+	Tx                []string `json:"tx"`
+	Time              int64    `json:"time"`
+	Nonce             uint64   `json:"nonce"`
+	Bits              string   `json:"bits"`
+	Difficulty        float64  `json:"difficulty"`
+	ChainWork         string   `json:"chainwork"`
+	Confirmations     uint64   `json:"confirmations"`
+	PreviousBlockHash string   `json:"previousblockhash,omitempty"`
+	NextBlockHash     string   `json:"nextblockhash,omitempty"`
+	Reward            float64  `json:"reward"`
+	IsMainChain       bool     `json:"isMainChain"`
+	PoolInfo          PoolInfo `json:"poolInfo"`
+}
+
+type BlockIndex struct {
+	BlockHash string `json:"blockHash"`
+}
+
+type BlockSummary struct {
+	Height   uint64   `json:"height"`
+	Size     uint64   `json:"size"`
+	Hash     string   `json:"hash"`
+	Time     int64    `json:"time"`
+	TxLength uint     `json:"txlength"`
+	PoolInfo PoolInfo `json:"poolInfo"`
+}
+
+type Blocks struct {
+	Blocks     []*BlockSummary   `json:"blocks"`
+	Length     int               `json:"length"`
+	Pagination *BlocksPagination `json:"pagination"`
+}
+
+// BlocksPagination moves between days, with More set when the day has blocks beyond the limit
+type BlocksPagination struct {
+	Next      string `json:"next"`
+	Prev      string `json:"prev"`
+	CurrentTs int64  `json:"currentTs"`
+	Current   string `json:"current"`
+	IsToday   bool   `json:"isToday"`
+	More      bool   `json:"more"`
+	MoreTs    int64  `json:"moreTs,omitempty"`
+}
@@ -0,0 +1,41 @@
+package entity
+
+type Info struct {
+	Version         int     `json:"version"`
+	ProtocolVersion int     `json:"protocolversion"`
+	Blocks          uint64  `json:"blocks"`
+	TimeOffset      int     `json:"timeoffset"`
+	Connections     int     `json:"connections"`
+	Proxy           string  `json:"proxy"`
+	Difficulty      float64 `json:"difficulty"`
+	Testnet         bool    `json:"testnet"`
+	RelayFee        float64 `json:"relayfee"`
+	Errors          string  `json:"errors"`
+	Network         string  `json:"network"`
+}
+
+type InfoStatus struct {
+	Info *Info `json:"info"`
+}
+
+type DifficultyStatus struct {
+	Difficulty float64 `json:"difficulty"`
+}
+
+type BestBlockHashStatus struct {
+	BestBlockHash string `json:"bestblockhash"`
+}
+
+type LastBlockHashStatus struct {
+	SyncTipHash   string `json:"syncTipHash"`
+	LastBlockHash string `json:"lastblockhash"`
+}
+
+type Sync struct {
+	Status           string  `json:"status"`
+	BlockChainHeight uint64  `json:"blockChainHeight"`
+	SyncPercentage   float64 `json:"syncPercentage"`
+	Height           uint64  `json:"height"`
+	Error            *string `json:"error"`
+	Type             string  `json:"type"`
+}
@@ -0,0 +1,63 @@
+package entity
+
+type Transaction struct {
+	Txid          string    `json:"txid"`
+	Version       uint32    `json:"version"`
+	LockTime      uint32    `json:"locktime"`
+	Vin           []*Input  `json:"vin"`
+	Vout          []*Output `json:"vout"`
+	BlockHash     string    `json:"blockhash"`
+	BlockHeight   uint64    `json:"blockheight"`
+	Confirmations uint64    `json:"confirmations"`
+	Time          int64     `json:"time"`
+	BlockTime     int64     `json:"blocktime"`
+	IsCoinBase    bool      `json:"isCoinBase,omitempty"`
+	ValueOut      float64   `json:"valueOut"`
+	Size          uint64    `json:"size"`
+	ValueIn       float64   `json:"valueIn,omitempty"`
+	Fees          float64   `json:"fees,omitempty"`
+}
+
+// Input has only the coinbase and sequence when it spends nothing
+type Input struct {
+	Coinbase  string     `json:"coinbase,omitempty"`
+	Txid      string     `json:"txid,omitempty"`
+	Vout      *int       `json:"vout,omitempty"`
+	Sequence  uint32     `json:"sequence"`
+	N         int        `json:"n"`
+	ScriptSig *ScriptSig `json:"scriptSig,omitempty"`
+	Addr      string     `json:"addr,omitempty"`
+	ValueSat  *uint64    `json:"valueSat,omitempty"`
+	Value     *float64   `json:"value,omitempty"`
+}
+
+type ScriptSig struct {
+	Hex string `json:"hex"`
+	Asm string `json:"asm"`
+}
+
+// Output values are strings with eight decimals, and the spent fields are null until the output is spent
+type Output struct {
+	Value        string        `json:"value"`
+	N            int           `json:"n"`
+	ScriptPubKey *ScriptPubKey `json:"scriptPubKey"`
+	SpentTxId    *string       `json:"spentTxId"`
+	SpentIndex   *int          `json:"spentIndex"`
+	SpentHeight  *uint64       `json:"spentHeight"`
+}
+
+type ScriptPubKey struct {
+	Hex       string   `json:"hex"`
+	Asm       string   `json:"asm"`
+	Addresses []string `json:"addresses,omitempty"`
+	Type      string   `json:"type"`
+}
+
+type Transactions struct {
+	PagesTotal int            `json:"pagesTotal"`
+	Txs        []*Transaction `json:"txs"`
+}
+
+type RawTransaction struct {
+	RawTx string `json:"rawtx"`
+}
@@ -0,0 +1,579 @@
+package insight
+
+import (
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/insight/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultBlocksLimit = 200
+	MaxBlocksLimit     = 1000
+	// Insight lists the transactions of an address from 0 to 1000 unless given a range
+	DefaultAddressTransactions = 1000
+	// Transaction ids are read from the newest, so deep pages are capped to keep each search small
+	maxAddressTransactionIndex = 10000
+	transactionsPageSize       = 10
+	blockDateFormat            = "2006-01-02"
+)
+
+var (
+	ErrStatusQueryInvalid        = errors.New("Query must be getInfo, getDifficulty, getBestBlockHash or getLastBlockHash")
+	ErrBlockDateInvalid          = errors.New("Block date must be formatted YYYY-MM-DD")
+	ErrBlocksLimitInvalid        = fmt.Errorf("Limit must be between 1 and %d", MaxBlocksLimit)
+	ErrAddressInvalid            = errors.New("Invalid address")
+	ErrAddressRangeInvalid       = fmt.Errorf("From must be before to, at most %d transactions apart and to at most %d", DefaultAddressTransactions, maxAddressTransactionIndex)
+	ErrTransactionsQueryInvalid  = errors.New("Transactions are listed by block or address")
+	ErrTransactionsPageInvalid   = errors.New("Page number must not be negative")
+	ErrTransactionNotFound       = errors.New("Transaction not found")
+	ErrRawTransactionUnavailable = errors.New("Raw transaction is not available")
+)
+
+func IsInsightError(err error) bool {
+	return err == ErrStatusQueryInvalid ||
+		err == ErrBlockDateInvalid ||
+		err == ErrBlocksLimitInvalid ||
+		err == ErrAddressInvalid ||
+		err == ErrAddressRangeInvalid ||
+		err == ErrTransactionsQueryInvalid ||
+		err == ErrTransactionsPageInvalid
+}
+
+// Service answers in the response shapes of the Bitpay Insight API so existing wallet libraries can use the explorer
+type Service interface {
+	GetStatus(n network.Network, query string) (interface{}, error)
+	GetSync(n network.Network) (*entity.Sync, error)
+	GetBlock(n network.Network, hash string) (*entity.Block, error)
+	GetBlockIndex(n network.Network, height uint64) (*entity.BlockIndex, error)
+	GetBlocks(n network.Network, blockDate string, limit int) (*entity.Blocks, error)
+	GetTransaction(n network.Network, txid string) (*entity.Transaction, error)
+	GetRawTransaction(n network.Network, txid string) (*entity.RawTransaction, error)
+	GetBlockTransactions(n network.Network, hash string, page int) (*entity.Transactions, error)
+	GetAddressTransactions(n network.Network, hash string, page int) (*entity.Transactions, error)
+	GetAddress(n network.Network, hash string, from, to int, noTxList bool) (*entity.Address, error)
+	GetUnspentOutputs(n network.Network, hashes []string) ([]*entity.Utxo, error)
+}
+
+type service struct {
+	addressService address.Service
+	blockService   block.Service
+}
+
+func NewInsightService(addressService address.Service, blockService block.Service) Service {
+	return &service{addressService, blockService}
+}
+
+// GetStatus answers the status queries of Insight, an empty query is getInfo.
+// Node details such as the version and connections are unknown to the explorer and left empty.
+func (s *service) GetStatus(n network.Network, query string) (interface{}, error) {
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	switch query {
+	case "", "getInfo":
+		return &entity.InfoStatus{Info: &entity.Info{
+			Blocks:     bestBlock.Height,
+			Difficulty: difficulty(bestBlock),
+			Testnet:    n.Name != "mainnet",
+			Network:    n.Name,
+		}}, nil
+	case "getDifficulty":
+		return &entity.DifficultyStatus{Difficulty: difficulty(bestBlock)}, nil
+	case "getBestBlockHash":
+		return &entity.BestBlockHashStatus{BestBlockHash: bestBlock.Hash}, nil
+	case "getLastBlockHash":
+		return &entity.LastBlockHashStatus{SyncTipHash: bestBlock.Hash, LastBlockHash: bestBlock.Hash}, nil
+	}
+
+	return nil, ErrStatusQueryInvalid
+}
+
+// GetSync reports the index as synced to the best block, the indexer is the source of every response
+func (s *service) GetSync(n network.Network) (*entity.Sync, error) {
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Sync{
+		Status:           "finished",
+		BlockChainHeight: bestBlock.Height,
+		SyncPercentage:   100,
+		Height:           bestBlock.Height,
+		Type:             "navexplorer",
+	}, nil
+}
+
+func (s *service) GetBlock(n network.Network, hash string) (*entity.Block, error) {
+	b, err := s.blockService.GetBlock(n, hash)
+	if err != nil {
+		return nil, err
+	}
+	// The block service also finds blocks by height, which Insight only does through the block index
+	if b.Hash != hash {
+		return nil, repository.ErrBlockNotFound
+	}
+
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Block{
+		Hash:              b.Hash,
+		Size:              b.Size,
+		Height:            b.Height,
+		Version:           b.Version,
+		MerkleRoot:        b.Merkleroot,
+		Tx:                b.Tx,
+		Time:              b.Time.Unix(),
+		Nonce:             b.Nonce,
+		Bits:              b.Bits,
+		Difficulty:        difficulty(b),
+		ChainWork:         b.Chainwork,
+		Confirmations:     confirmations(bestBlock, b.Height),
+		PreviousBlockHash: b.Previousblockhash,
+		NextBlockHash:     b.Nextblockhash,
+		Reward:            nav(b.Stake),
+		IsMainChain:       true,
+	}, nil
+}
+
+func (s *service) GetBlockIndex(n network.Network, height uint64) (*entity.BlockIndex, error) {
+	b, err := s.blockService.GetBlock(n, strconv.FormatUint(height, 10))
+	if err != nil {
+		return nil, err
+	}
+	if b.Height != height {
+		return nil, repository.ErrBlockNotFound
+	}
+
+	return &entity.BlockIndex{BlockHash: b.Hash}, nil
+}
+
+// GetBlocks lists the blocks of a day, newest first, with an empty blockDate being today
+func (s *service) GetBlocks(n network.Network, blockDate string, limit int) (*entity.Blocks, error) {
+	if limit < 1 || limit > MaxBlocksLimit {
+		return nil, ErrBlocksLimitInvalid
+	}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	date := today
+	if blockDate != "" {
+		parsed, err := time.Parse(blockDateFormat, blockDate)
+		if err != nil {
+			return nil, ErrBlockDateInvalid
+		}
+		date = parsed
+	}
+	end := date.AddDate(0, 0, 1)
+
+	filters := framework.NewFilters(framework.FilterOptions{
+		framework.NewFilterExpression("time", framework.FilterGreaterThanOrEqual, false, []interface{}{date.Format(time.RFC3339)}),
+		framework.NewFilterExpression("time", framework.FilterLessThan, false, []interface{}{end.Format(time.RFC3339)}),
+	})
+	request := framework.NewRestRequest(n, framework.NewPagination(1, limit), filters, framework.NewSort(nil))
+	blocks, total, err := s.blockService.GetBlocks(n, request)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*entity.BlockSummary, 0, len(blocks))
+	for _, b := range blocks {
+		summaries = append(summaries, &entity.BlockSummary{
+			Height:   b.Height,
+			Size:     b.Size,
+			Hash:     b.Hash,
+			Time:     b.Time.Unix(),
+			TxLength: b.TxCount,
+		})
+	}
+
+	pagination := &entity.BlocksPagination{
+		Next:      end.Format(blockDateFormat),
+		Prev:      date.AddDate(0, 0, -1).Format(blockDateFormat),
+		CurrentTs: end.Unix() - 1,
+		Current:   date.Format(blockDateFormat),
+		IsToday:   date.Equal(today),
+		More:      total > int64(len(blocks)),
+	}
+	if pagination.More && len(blocks) != 0 {
+		pagination.MoreTs = blocks[len(blocks)-1].Time.Unix()
+	}
+
+	return &entity.Blocks{Blocks: summaries, Length: len(summaries), Pagination: pagination}, nil
+}
+
+func (s *service) GetTransaction(n network.Network, txid string) (*entity.Transaction, error) {
+	tx, err := s.getTransaction(n, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := s.transactions(n, []*explorer.BlockTransaction{tx}, bestBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return txs[0], nil
+}
+
+func (s *service) GetRawTransaction(n network.Network, txid string) (*entity.RawTransaction, error) {
+	tx, err := s.blockService.GetRawTransactionByHash(n, txid)
+	if err == repository.ErrBlockNotFound {
+		return nil, ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tx.Hex == "" {
+		return nil, ErrRawTransactionUnavailable
+	}
+
+	return &entity.RawTransaction{RawTx: tx.Hex}, nil
+}
+
+// GetBlockTransactions lists the transactions of a block in pages of ten, numbered from 0
+func (s *service) GetBlockTransactions(n network.Network, hash string, page int) (*entity.Transactions, error) {
+	if page < 0 {
+		return nil, ErrTransactionsPageInvalid
+	}
+
+	b, err := s.blockService.GetBlock(n, hash)
+	if err != nil {
+		return nil, err
+	}
+	if b.Hash != hash {
+		return nil, repository.ErrBlockNotFound
+	}
+
+	txs, err := s.blockService.GetTransactionsByBlockHash(n, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := page*transactionsPageSize, (page+1)*transactionsPageSize
+	if start > len(txs) {
+		start = len(txs)
+	}
+	if end > len(txs) {
+		end = len(txs)
+	}
+
+	pageTxs, err := s.transactions(n, txs[start:end], bestBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Transactions{PagesTotal: pages(int64(len(txs))), Txs: pageTxs}, nil
+}
+
+// GetAddressTransactions lists the transactions of an address newest first in pages of ten, numbered from 0
+func (s *service) GetAddressTransactions(n network.Network, hash string, page int) (*entity.Transactions, error) {
+	if page < 0 {
+		return nil, ErrTransactionsPageInvalid
+	}
+	if page >= maxAddressTransactionIndex/transactionsPageSize {
+		return &entity.Transactions{Txs: make([]*entity.Transaction, 0)}, nil
+	}
+	if err := s.validateAddress(n, hash); err != nil {
+		return nil, err
+	}
+
+	request := framework.NewRestRequest(n, framework.NewPagination(page+1, transactionsPageSize), framework.NewFilters(nil), framework.NewSort(nil))
+	history, total, err := s.addressService.GetHistory(n, hash, request)
+	if err != nil {
+		return nil, err
+	}
+
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*explorer.BlockTransaction, 0, len(history))
+	for _, h := range history {
+		tx, err := s.getTransaction(n, h.TxId)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+
+	pageTxs, err := s.transactions(n, txs, bestBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Transactions{PagesTotal: pages(total), Txs: pageTxs}, nil
+}
+
+// GetAddress summarises the spendable balance of an address, with the ids of its transactions from and to, newest first.
+// Staking rewards are counted as received, and an address without history has nothing received or sent.
+func (s *service) GetAddress(n network.Network, hash string, from, to int, noTxList bool) (*entity.Address, error) {
+	if !noTxList && (from < 0 || to <= from || to-from > DefaultAddressTransactions || to > maxAddressTransactionIndex) {
+		return nil, ErrAddressRangeInvalid
+	}
+	if err := s.validateAddress(n, hash); err != nil {
+		return nil, err
+	}
+
+	a := &entity.Address{AddrStr: hash}
+	summary, err := s.addressService.GetAddressSummary(n, hash)
+	if err == repository.ErrAddressNotFound {
+		if !noTxList {
+			a.Transactions = make([]string, 0)
+		}
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.BalanceSat = summary.Spendable.Balance
+	a.TotalReceivedSat = summary.Spendable.Received + summary.Spendable.Staked
+	a.TotalSentSat = -summary.Spendable.Sent
+	a.Balance = navSigned(a.BalanceSat)
+	a.TotalReceived = navSigned(a.TotalReceivedSat)
+	a.TotalSent = navSigned(a.TotalSentSat)
+	a.TxApperances = summary.Txs
+
+	if noTxList {
+		return a, nil
+	}
+
+	request := framework.NewRestRequest(n, framework.NewPagination(1, to), framework.NewFilters(nil), framework.NewSort(nil))
+	history, _, err := s.addressService.GetHistory(n, hash, request)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Transactions = make([]string, 0)
+	for i := from; i < len(history); i++ {
+		a.Transactions = append(a.Transactions, history[i].TxId)
+	}
+
+	return a, nil
+}
+
+// GetUnspentOutputs lists the unspent outputs of the addresses newest first, as Insight does
+func (s *service) GetUnspentOutputs(n network.Network, hashes []string) ([]*entity.Utxo, error) {
+	for _, hash := range hashes {
+		if err := s.validateAddress(n, hash); err != nil {
+			return nil, err
+		}
+	}
+
+	outputs, err := s.addressService.GetUnspentOutputs(n, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	bestBlock, err := s.blockService.GetBestBlock(n)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos := make([]*entity.Utxo, 0, len(outputs))
+	for i := len(outputs) - 1; i >= 0; i-- {
+		o := outputs[i]
+		utxos = append(utxos, &entity.Utxo{
+			Address:       o.Address,
+			Txid:          o.Txid,
+			Vout:          o.Vout,
+			ScriptPubKey:  o.ScriptPubKey,
+			Amount:        nav(o.Value),
+			Satoshis:      o.Value,
+			Height:        o.Height,
+			Confirmations: confirmations(bestBlock, o.Height),
+			Ts:            o.Time.Unix(),
+		})
+	}
+
+	return utxos, nil
+}
+
+func (s *service) getTransaction(n network.Network, txid string) (*explorer.BlockTransaction, error) {
+	tx, err := s.blockService.GetTransactionByHash(n, txid)
+	if err == repository.ErrBlockNotFound {
+		return nil, ErrTransactionNotFound
+	}
+
+	return tx, err
+}
+
+// transactions maps the transactions with the transactions spending their outputs, to find the spending inputs
+func (s *service) transactions(n network.Network, txs []*explorer.BlockTransaction, bestBlock *explorer.Block) ([]*entity.Transaction, error) {
+	spenderTxids := make([]string, 0)
+	for _, tx := range txs {
+		for _, vout := range tx.Vout {
+			if vout.Redeemed && vout.RedeemedIn != nil {
+				spenderTxids = append(spenderTxids, vout.RedeemedIn.Hash)
+			}
+		}
+	}
+
+	spenders, err := s.blockService.GetTransactionsByTxids(n, spenderTxids)
+	if err != nil {
+		return nil, err
+	}
+
+	spending := make(map[string]*explorer.BlockTransaction)
+	for _, spender := range spenders {
+		spending[spender.Txid] = spender
+	}
+
+	result := make([]*entity.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		result = append(result, transaction(tx, bestBlock, spending))
+	}
+
+	return result, nil
+}
+
+func (s *service) validateAddress(n network.Network, hash string) error {
+	validation, err := s.addressService.ValidateAddress(n, hash)
+	if err != nil {
+		return err
+	}
+	if !validation.Valid {
+		return ErrAddressInvalid
+	}
+
+	return nil
+}
+
+func transaction(tx *explorer.BlockTransaction, bestBlock *explorer.Block, spending map[string]*explorer.BlockTransaction) *entity.Transaction {
+	t := &entity.Transaction{
+		Txid:          tx.Txid,
+		Version:       tx.Version,
+		LockTime:      tx.LockTime,
+		Vin:           make([]*entity.Input, 0, len(tx.Vin)),
+		Vout:          make([]*entity.Output, 0, len(tx.Vout)),
+		BlockHash:     tx.BlockHash,
+		BlockHeight:   tx.Height,
+		Confirmations: confirmations(bestBlock, tx.Height),
+		Time:          tx.Time.Unix(),
+		BlockTime:     tx.BlockTime.Unix(),
+		Size:          tx.Size,
+		Fees:          nav(tx.Fees),
+	}
+
+	var valueIn, valueOut uint64
+	for i, vin := range tx.Vin {
+		input := &entity.Input{Sequence: vin.Sequence, N: i}
+		if vin.IsCoinbase() {
+			input.Coinbase = vin.Coinbase
+			t.IsCoinBase = true
+			t.Vin = append(t.Vin, input)
+			continue
+		}
+
+		if vin.Txid != nil {
+			input.Txid = *vin.Txid
+		}
+		input.Vout = vin.Vout
+		if vin.ScriptSig != nil {
+			input.ScriptSig = &entity.ScriptSig{Hex: vin.ScriptSig.Hex, Asm: vin.ScriptSig.Asm}
+		}
+		if len(vin.Addresses) != 0 {
+			input.Addr = vin.Addresses[0]
+		}
+		valueSat := vin.ValueSat
+		value := nav(valueSat)
+		input.ValueSat = &valueSat
+		input.Value = &value
+		valueIn += valueSat
+
+		t.Vin = append(t.Vin, input)
+	}
+
+	for _, vout := range tx.Vout {
+		output := &entity.Output{
+			Value: strconv.FormatFloat(nav(vout.ValueSat), 'f', 8, 64),
+			N:     vout.N,
+			ScriptPubKey: &entity.ScriptPubKey{
+				Hex:       vout.ScriptPubKey.Hex,
+				Asm:       vout.ScriptPubKey.Asm,
+				Addresses: vout.ScriptPubKey.Addresses,
+				Type:      string(vout.ScriptPubKey.Type),
+			},
+		}
+		if vout.Redeemed && vout.RedeemedIn != nil {
+			output.SpentTxId = &vout.RedeemedIn.Hash
+			output.SpentIndex = spentIndex(tx.Txid, vout.N, spending[vout.RedeemedIn.Hash])
+			output.SpentHeight = &vout.RedeemedIn.Height
+		}
+		valueOut += vout.ValueSat
+
+		t.Vout = append(t.Vout, output)
+	}
+
+	t.ValueIn = nav(valueIn)
+	t.ValueOut = nav(valueOut)
+
+	return t
+}
+
+// spentIndex is the index of the input of the spending transaction which spends the output,
+// the indexer only records the spending transaction and the index of the output itself
+func spentIndex(txid string, n int, spender *explorer.BlockTransaction) *int {
+	if spender == nil {
+		return nil
+	}
+	for i, vin := range spender.Vin {
+		if vin.Txid != nil && *vin.Txid == txid && vin.Vout != nil && *vin.Vout == n {
+			index := i
+			return &index
+		}
+	}
+
+	return nil
+}
+
+func confirmations(bestBlock *explorer.Block, height uint64) uint64 {
+	if height > bestBlock.Height {
+		return 0
+	}
+
+	return bestBlock.Height - height + 1
+}
+
+func difficulty(b *explorer.Block) float64 {
+	value, _ := strconv.ParseFloat(b.Difficulty, 64)
+
+	return value
+}
+
+func pages(total int64) int {
+	return int((total + transactionsPageSize - 1) / transactionsPageSize)
+}
+
+func nav(satoshi uint64) float64 {
+	return float64(satoshi) / 100000000
+}
+
+func navSigned(satoshi int64) float64 {
+	return float64(satoshi) / 100000000
+}
@@ -0,0 +1,50 @@
+package entity
+
+type SuggestionType string
+
+var (
+	SuggestBlock          SuggestionType = "block"
+	SuggestTransaction    SuggestionType = "transaction"
+	SuggestAddress        SuggestionType = "address"
+	SuggestProposal       SuggestionType = "proposal"
+	SuggestPaymentRequest SuggestionType = "paymentRequest"
+	SuggestConsultation   SuggestionType = "consultation"
+)
+
+var SuggestionTypes = []SuggestionType{
+	SuggestBlock,
+	SuggestTransaction,
+	SuggestAddress,
+	SuggestProposal,
+	SuggestPaymentRequest,
+	SuggestConsultation,
+}
+
+type SuggestionMatch string
+
+// Matches in rank order, an exact match ranks above a prefix match which ranks above a text match
+var (
+	MatchExact  SuggestionMatch = "exact"
+	MatchPrefix SuggestionMatch = "prefix"
+	MatchText   SuggestionMatch = "text"
+)
+
+type Suggestion struct {
+	Type   SuggestionType  `json:"type"`
+	Value  string          `json:"value"`
+	Height uint64          `json:"height,omitempty"`
+	Text   string          `json:"text,omitempty"`
+	Match  SuggestionMatch `json:"match"`
+	Score  float64         `json:"score"`
+}
+
+func (s *Suggestion) Rank() int {
+	switch s.Match {
+	case MatchExact:
+		return 3
+	case MatchPrefix:
+		return 2
+	default:
+		return 1
+	}
+}
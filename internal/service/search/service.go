@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/search/entity"
+	"go.uber.org/zap"
+	"sort"
+	"sync"
+)
+
+const MaxSuggestLimit = 20
+
+var ErrSuggestFailed = errors.New("Failed to search")
+
+type Service interface {
+	Suggest(ctx context.Context, n network.Network, query string, types []entity.SuggestionType, limit int) ([]*entity.Suggestion, error)
+}
+
+type service struct {
+	searchRepository repository.SearchRepository
+}
+
+func NewSearchService(searchRepository repository.SearchRepository) Service {
+	return &service{searchRepository}
+}
+
+// Suggest searches each type concurrently for at most limit results and ranks them together.
+// A type that fails is left out, the search only fails when every type fails.
+func (s *service) Suggest(ctx context.Context, n network.Network, query string, types []entity.SuggestionType, limit int) ([]*entity.Suggestion, error) {
+	if limit < 1 || limit > MaxSuggestLimit {
+		limit = MaxSuggestLimit
+	}
+
+	results := make([][]*entity.Suggestion, len(types))
+	failures := make([]error, len(types))
+
+	var wg sync.WaitGroup
+	for i, suggestionType := range types {
+		wg.Add(1)
+		go func(i int, suggestionType entity.SuggestionType) {
+			defer wg.Done()
+			results[i], failures[i] = s.searchRepository.Suggest(ctx, n, suggestionType, query, limit)
+		}(i, suggestionType)
+	}
+	wg.Wait()
+
+	suggestions := make([]*entity.Suggestion, 0)
+	failed := 0
+	for i := range types {
+		if failures[i] != nil {
+			zap.L().With(zap.Error(failures[i]), zap.String("type", string(types[i]))).Error("Search: Failed to suggest")
+			failed++
+			continue
+		}
+		suggestions = append(suggestions, results[i]...)
+	}
+	if failed != 0 && failed == len(types) {
+		return nil, ErrSuggestFailed
+	}
+
+	typeOrder := make(map[entity.SuggestionType]int)
+	for i, suggestionType := range entity.SuggestionTypes {
+		typeOrder[suggestionType] = i
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Rank() != b.Rank() {
+			return a.Rank() > b.Rank()
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return typeOrder[a.Type] < typeOrder[b.Type]
+	})
+
+	return suggestions, nil
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+)
+
+type MessageType string
+
+var (
+	MessageBlock MessageType = "block"
+)
+
+type Message struct {
+	Type         MessageType                  `json:"type"`
+	Network      string                       `json:"network"`
+	Block        *explorer.Block              `json:"block"`
+	Transactions []*explorer.BlockTransaction `json:"transactions,omitempty"`
+}
@@ -0,0 +1,142 @@
+package stream
+
+import (
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/stream/entity"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"go.uber.org/zap"
+	"sync"
+)
+
+// The number of messages buffered for a subscriber before it is considered too slow and dropped
+const subscriberBuffer = 32
+
+type Service interface {
+	Subscribe(n network.Network, transactions bool) *Subscriber
+	Unsubscribe(subscriber *Subscriber)
+	Publish(n network.Network, block *explorer.Block)
+	Close()
+}
+
+type Subscriber struct {
+	Network      network.Network
+	Transactions bool
+	Messages     chan *entity.Message
+}
+
+type service struct {
+	transactionRepo repository.BlockTransactionRepository
+	subscribers     map[*Subscriber]bool
+	mu              sync.RWMutex
+}
+
+func NewStreamService(watcher block.Watcher, transactionRepo repository.BlockTransactionRepository) Service {
+	s := &service{
+		transactionRepo: transactionRepo,
+		subscribers:     make(map[*Subscriber]bool),
+	}
+	watcher.OnBlock(s.Publish)
+
+	return s
+}
+
+func (s *service) Subscribe(n network.Network, transactions bool) *Subscriber {
+	subscriber := &Subscriber{
+		Network:      n,
+		Transactions: transactions,
+		Messages:     make(chan *entity.Message, subscriberBuffer),
+	}
+
+	s.mu.Lock()
+	s.subscribers[subscriber] = true
+	s.mu.Unlock()
+
+	zap.S().Infof("Stream: Subscribed to %s", n.String())
+
+	return subscriber
+}
+
+func (s *service) Unsubscribe(subscriber *Subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[subscriber]; ok {
+		delete(s.subscribers, subscriber)
+		close(subscriber.Messages)
+		zap.S().Infof("Stream: Unsubscribed from %s", subscriber.Network.String())
+	}
+}
+
+// Close unsubscribes every subscriber so their connections are closed
+func (s *service) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subscriber := range s.subscribers {
+		delete(s.subscribers, subscriber)
+		close(subscriber.Messages)
+	}
+	zap.S().Info("Stream: Closed all subscribers")
+}
+
+func (s *service) Publish(n network.Network, block *explorer.Block) {
+	listening, withTransactions := s.listening(n)
+	if !listening {
+		return
+	}
+
+	var transactions []*explorer.BlockTransaction
+	if withTransactions {
+		txs, err := s.transactionRepo.GetTransactionsByBlock(n, block)
+		if err != nil {
+			zap.L().With(zap.Error(err), zap.String("hash", block.Hash)).Error("Stream: Failed to get transactions")
+		}
+		transactions = txs
+	}
+
+	blockMessage := &entity.Message{Type: entity.MessageBlock, Network: n.Name, Block: block}
+	transactionsMessage := &entity.Message{Type: entity.MessageBlock, Network: n.Name, Block: block, Transactions: transactions}
+
+	slow := make([]*Subscriber, 0)
+
+	// Sends happen under the read lock so Unsubscribe cannot close a channel mid-send
+	s.mu.RLock()
+	for subscriber := range s.subscribers {
+		if subscriber.Network.String() != n.String() {
+			continue
+		}
+
+		message := blockMessage
+		if subscriber.Transactions {
+			message = transactionsMessage
+		}
+
+		select {
+		case subscriber.Messages <- message:
+		default:
+			slow = append(slow, subscriber)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, subscriber := range slow {
+		zap.S().Warnf("Stream: Dropping slow subscriber on %s", n.String())
+		s.Unsubscribe(subscriber)
+	}
+}
+
+func (s *service) listening(n network.Network) (listening bool, withTransactions bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for subscriber := range s.subscribers {
+		if subscriber.Network.String() == n.String() {
+			listening = true
+			withTransactions = withTransactions || subscriber.Transactions
+		}
+	}
+
+	return
+}
@@ -0,0 +1,397 @@
+package watchlist
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist/entity"
+	"go.uber.org/zap"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Navexplorer-Signature"
+	TimestampHeader = "X-Navexplorer-Timestamp"
+	EventHeader     = "X-Navexplorer-Event"
+	DeliveryHeader  = "X-Navexplorer-Delivery"
+
+	// The number of deliveries queued before new deliveries are dropped
+	deliveryQueue = 1000
+	// The number of recent deliveries kept for each watchlist
+	recentDeliveries = 100
+	maxBackoff       = time.Hour
+)
+
+// Sign is the hex HMAC-SHA256 of the timestamp and body, joined by a dot, keyed by the watchlist secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type dispatcher struct {
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	logPath     string
+	store       Store
+
+	queue   chan *entity.PendingDelivery
+	stop    chan bool
+	timers  map[string]*time.Timer
+	active  map[string]bool
+	recent  map[string][]*entity.Delivery
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+func newDispatcher(
+	client *http.Client,
+	maxAttempts int,
+	backoff time.Duration,
+	workers int,
+	logPath string,
+	store Store,
+) *dispatcher {
+	d := &dispatcher{
+		client:      client,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		logPath:     logPath,
+		store:       store,
+		queue:       make(chan *entity.PendingDelivery, deliveryQueue),
+		stop:        make(chan bool),
+		timers:      make(map[string]*time.Timer),
+		active:      make(map[string]bool),
+		recent:      make(map[string][]*entity.Delivery),
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+
+	d.resume()
+
+	return d
+}
+
+// resume restarts the deliveries saved in the store, retries not yet due wait for their next attempt
+func (d *dispatcher) resume() {
+	pending := d.store.Pending()
+	for _, p := range pending {
+		d.remember(p.Delivery)
+		d.mu.Lock()
+		d.active[p.Delivery.Id] = true
+		d.mu.Unlock()
+
+		if p.Delivery.NextAttempt != nil {
+			if wait := time.Until(*p.Delivery.NextAttempt); wait > 0 {
+				d.retry(p, wait)
+				continue
+			}
+		}
+		d.enqueue(p)
+	}
+
+	if len(pending) > 0 {
+		zap.S().Infof("Watchlist: Resumed %d pending deliveries", len(pending))
+	}
+}
+
+// dispatch saves the delivery of the event before queueing it and returns a copy of it as queued
+func (d *dispatcher) dispatch(event *entity.Event) (*entity.Delivery, error) {
+	pending, err := d.prepare(event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.store.PutPending(pending); err != nil {
+		return nil, err
+	}
+
+	return d.start(pending), nil
+}
+
+// prepare creates the delivery of the event without queueing it, so it can be saved first
+func (d *dispatcher) prepare(event *entity.Event) (*entity.PendingDelivery, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &entity.Delivery{
+		Id:        event.Id,
+		Watchlist: event.Watchlist,
+		Type:      event.Type,
+		Address:   event.Address,
+		Status:    entity.DeliveryPending,
+		Created:   event.Time,
+		Updated:   event.Time,
+	}
+	if event.History != nil {
+		record.Height = event.History.Height
+		record.TxId = event.History.TxId
+	}
+	if event.Dao != nil {
+		record.Height = event.Dao.Height
+		record.Hash = event.Dao.Hash
+	}
+
+	return &entity.PendingDelivery{Delivery: record, Body: body}, nil
+}
+
+// start queues a saved delivery and returns a copy of it as queued, a delivery already started is not queued twice
+func (d *dispatcher) start(pending *entity.PendingDelivery) *entity.Delivery {
+	d.mu.Lock()
+	if d.active[pending.Delivery.Id] {
+		d.mu.Unlock()
+		return nil
+	}
+	d.active[pending.Delivery.Id] = true
+	d.mu.Unlock()
+
+	snapshot := d.remember(pending.Delivery)
+	d.enqueue(pending)
+
+	return snapshot
+}
+
+// deliveries returns the recent deliveries of the watchlist, newest first
+func (d *dispatcher) deliveries(id string) []*entity.Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deliveries := make([]*entity.Delivery, 0, len(d.recent[id]))
+	for i := len(d.recent[id]) - 1; i >= 0; i-- {
+		record := *d.recent[id][i]
+		deliveries = append(deliveries, &record)
+	}
+
+	return deliveries
+}
+
+func (d *dispatcher) forget(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.recent, id)
+}
+
+// close stops the workers once their current attempt finishes, queued deliveries and retries not yet due stay
+// in the store and resume when the dispatcher is next created
+func (d *dispatcher) close() {
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		return
+	}
+	d.stopped = true
+	for id, timer := range d.timers {
+		timer.Stop()
+		delete(d.timers, id)
+	}
+	close(d.stop)
+	d.mu.Unlock()
+
+	d.wg.Wait()
+	zap.S().Infof("Watchlist: Stopped delivering, %d deliveries were queued", len(d.queue))
+}
+
+func (d *dispatcher) enqueue(pending *entity.PendingDelivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return
+	}
+
+	select {
+	case d.queue <- pending:
+	default:
+		zap.L().With(zap.String("delivery", pending.Delivery.Id)).Error("Watchlist: Delivery queue is full, dropping delivery")
+		d.finish(pending, entity.DeliveryFailed, 0, "Delivery queue is full")
+	}
+}
+
+func (d *dispatcher) work() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case pending := <-d.queue:
+			d.attempt(pending)
+		case <-d.stop:
+			return
+		}
+	}
+}
+
+func (d *dispatcher) attempt(pending *entity.PendingDelivery) {
+	watchlist, err := d.store.Get(pending.Delivery.Watchlist)
+	if err != nil {
+		d.mu.Lock()
+		d.finish(pending, entity.DeliveryFailed, 0, err.Error())
+		d.mu.Unlock()
+		return
+	}
+
+	statusCode, err := d.send(watchlist, pending)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	record := pending.Delivery
+	record.Attempts++
+	if err == nil {
+		d.finish(pending, entity.DeliveryDelivered, statusCode, "")
+		return
+	}
+	if record.Attempts >= d.maxAttempts {
+		d.finish(pending, entity.DeliveryFailed, statusCode, err.Error())
+		return
+	}
+
+	// Each retry waits twice as long as the last
+	wait := d.backoff << uint(record.Attempts-1)
+	if wait > maxBackoff || wait <= 0 {
+		wait = maxBackoff
+	}
+	next := time.Now().UTC().Add(wait)
+	record.NextAttempt = &next
+	d.finish(pending, entity.DeliveryPending, statusCode, err.Error())
+
+	d.retryLocked(pending, wait)
+}
+
+// retry queues the delivery again once wait has passed
+func (d *dispatcher) retry(pending *entity.PendingDelivery, wait time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.retryLocked(pending, wait)
+}
+
+// retryLocked is retry for a caller holding the lock
+func (d *dispatcher) retryLocked(pending *entity.PendingDelivery, wait time.Duration) {
+	if d.stopped {
+		return
+	}
+
+	d.timers[pending.Delivery.Id] = time.AfterFunc(wait, func() {
+		d.mu.Lock()
+		delete(d.timers, pending.Delivery.Id)
+		d.mu.Unlock()
+		d.enqueue(pending)
+	})
+}
+
+func (d *dispatcher) send(watchlist *entity.Watchlist, pending *entity.PendingDelivery) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, watchlist.Url, bytes.NewReader(pending.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "NavExplorer-Webhook")
+	request.Header.Set(EventHeader, string(pending.Delivery.Type))
+	request.Header.Set(DeliveryHeader, pending.Delivery.Id)
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, "sha256="+Sign(watchlist.Secret, timestamp, pending.Body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("Webhook responded with %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// finish records the outcome of an attempt and saves a pending delivery or removes a finished one from the store,
+// the caller holds the lock
+func (d *dispatcher) finish(pending *entity.PendingDelivery, status entity.DeliveryStatus, statusCode int, message string) {
+	record := pending.Delivery
+	record.Status = status
+	record.StatusCode = statusCode
+	record.Error = message
+	record.Updated = time.Now().UTC()
+	if status != entity.DeliveryPending {
+		record.NextAttempt = nil
+	}
+
+	d.log(record)
+
+	var err error
+	if status == entity.DeliveryPending {
+		err = d.store.PutPending(pending)
+	} else {
+		delete(d.active, record.Id)
+		err = d.store.DeletePending(record.Id)
+	}
+	if err != nil {
+		zap.L().With(zap.Error(err), zap.String("delivery", record.Id)).Error("Watchlist: Failed to save delivery")
+	}
+}
+
+// remember keeps the delivery among the recent deliveries and returns a copy of it as queued
+func (d *dispatcher) remember(record *entity.Delivery) *entity.Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	recent := append(d.recent[record.Watchlist], record)
+	if len(recent) > recentDeliveries {
+		recent = recent[len(recent)-recentDeliveries:]
+	}
+	d.recent[record.Watchlist] = recent
+
+	snapshot := *record
+
+	return &snapshot
+}
+
+// log appends the delivery to the delivery log as a line of JSON, the caller holds the lock
+func (d *dispatcher) log(record *entity.Delivery) {
+	line, err := json.Marshal(record)
+	if err == nil {
+		err = appendLine(d.logPath, line)
+	}
+	if err != nil {
+		zap.L().With(zap.Error(err), zap.String("delivery", record.Id)).Error("Watchlist: Failed to log delivery")
+	}
+}
+
+func appendLine(path string, line []byte) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
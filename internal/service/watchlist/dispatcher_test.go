@@ -0,0 +1,305 @@
+package watchlist
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist/entity"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testWebhook struct {
+	server   *httptest.Server
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+	mu       sync.Mutex
+}
+
+// newTestWebhook responds to each request with the next of statuses, and the last one once they run out
+func newTestWebhook(t *testing.T, statuses ...int) *testWebhook {
+	w := &testWebhook{statuses: statuses}
+	w.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		w.mu.Lock()
+		status := w.statuses[len(w.statuses)-1]
+		if len(w.requests) < len(w.statuses) {
+			status = w.statuses[len(w.requests)]
+		}
+		w.requests = append(w.requests, r)
+		w.bodies = append(w.bodies, body)
+		w.mu.Unlock()
+
+		rw.WriteHeader(status)
+	}))
+	t.Cleanup(w.server.Close)
+
+	return w
+}
+
+func (w *testWebhook) count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.requests)
+}
+
+func (w *testWebhook) request(i int) (*http.Request, []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.requests[i], w.bodies[i]
+}
+
+func newTestStore(t *testing.T, url string) (Store, string) {
+	dir, err := ioutil.TempDir("", "watchlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	store, err := NewFileStore(filepath.Join(dir, "watchlists.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Put(&entity.Watchlist{
+		Id:        "watchlist",
+		Network:   "mainnet",
+		Url:       url,
+		Secret:    "secret",
+		Events:    []entity.EventType{entity.EventReceive},
+		Addresses: []string{"address"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store, dir
+}
+
+func newTestDispatcher(t *testing.T, store Store, dir string, maxAttempts int) *dispatcher {
+	d := newDispatcher(http.DefaultClient, maxAttempts, time.Millisecond, 1, filepath.Join(dir, "webhook.log"), store)
+	t.Cleanup(d.close)
+
+	return d
+}
+
+func testEvent() *entity.Event {
+	return &entity.Event{
+		Id:        "event",
+		Type:      entity.EventReceive,
+		Network:   "mainnet",
+		Watchlist: "watchlist",
+		Address:   "address",
+		Time:      time.Now().UTC(),
+	}
+}
+
+// waitForDelivery waits until the latest delivery of the watchlist is delivered or failed
+func waitForDelivery(t *testing.T, d *dispatcher) *entity.Delivery {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		deliveries := d.deliveries("watchlist")
+		if len(deliveries) != 0 && deliveries[0].Status != entity.DeliveryPending {
+			return deliveries[0]
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("Delivery did not finish")
+
+	return nil
+}
+
+func readDeliveryLog(t *testing.T, dir string) []*entity.Delivery {
+	file, err := os.Open(filepath.Join(dir, "webhook.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	records := make([]*entity.Delivery, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := new(entity.Delivery)
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatalf("Delivery log line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestDispatcherSignsDelivery(t *testing.T) {
+	webhook := newTestWebhook(t, http.StatusOK)
+	store, dir := newTestStore(t, webhook.server.URL)
+	d := newTestDispatcher(t, store, dir, 3)
+
+	if _, err := d.dispatch(testEvent()); err != nil {
+		t.Fatal(err)
+	}
+	if delivery := waitForDelivery(t, d); delivery.Status != entity.DeliveryDelivered {
+		t.Fatalf("Delivery status = %s, want %s", delivery.Status, entity.DeliveryDelivered)
+	}
+
+	request, body := webhook.request(0)
+	if request.Header.Get(EventHeader) != string(entity.EventReceive) {
+		t.Errorf("%s = %q, want %q", EventHeader, request.Header.Get(EventHeader), entity.EventReceive)
+	}
+	if request.Header.Get(DeliveryHeader) != "event" {
+		t.Errorf("%s = %q, want %q", DeliveryHeader, request.Header.Get(DeliveryHeader), "event")
+	}
+
+	timestamp := request.Header.Get(TimestampHeader)
+	if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+		t.Fatalf("%s = %q is not a unix timestamp", TimestampHeader, timestamp)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(timestamp + "." + string(body)))
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); request.Header.Get(SignatureHeader) != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, request.Header.Get(SignatureHeader), want)
+	}
+
+	event := new(entity.Event)
+	if err := json.Unmarshal(body, event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Id != "event" || event.Address != "address" {
+		t.Errorf("Event = %+v, want the dispatched event", event)
+	}
+}
+
+func TestDispatcherRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		status   entity.DeliveryStatus
+		attempts int
+		logged   []entity.DeliveryStatus
+	}{
+		{
+			name:     "fails after max attempts",
+			statuses: []int{http.StatusInternalServerError},
+			status:   entity.DeliveryFailed,
+			attempts: 3,
+			logged:   []entity.DeliveryStatus{entity.DeliveryPending, entity.DeliveryPending, entity.DeliveryFailed},
+		},
+		{
+			name:     "delivered after a retry",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusNoContent},
+			status:   entity.DeliveryDelivered,
+			attempts: 2,
+			logged:   []entity.DeliveryStatus{entity.DeliveryPending, entity.DeliveryDelivered},
+		},
+		{
+			name:     "redirect is not a success",
+			statuses: []int{http.StatusFound},
+			status:   entity.DeliveryFailed,
+			attempts: 3,
+			logged:   []entity.DeliveryStatus{entity.DeliveryPending, entity.DeliveryPending, entity.DeliveryFailed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := newTestWebhook(t, tt.statuses...)
+			store, dir := newTestStore(t, webhook.server.URL)
+			d := newTestDispatcher(t, store, dir, 3)
+
+			if _, err := d.dispatch(testEvent()); err != nil {
+				t.Fatal(err)
+			}
+
+			delivery := waitForDelivery(t, d)
+			if delivery.Status != tt.status || delivery.Attempts != tt.attempts {
+				t.Errorf("Delivery = %s after %d attempts, want %s after %d", delivery.Status, delivery.Attempts, tt.status, tt.attempts)
+			}
+			if webhook.count() != tt.attempts {
+				t.Errorf("Webhook received %d requests, want %d", webhook.count(), tt.attempts)
+			}
+			if pending := store.Pending(); len(pending) != 0 {
+				t.Errorf("Store has %d pending deliveries, want none", len(pending))
+			}
+
+			records := readDeliveryLog(t, dir)
+			if len(records) != len(tt.logged) {
+				t.Fatalf("Delivery log has %d records, want %d", len(records), len(tt.logged))
+			}
+			for i, record := range records {
+				if record.Id != "event" || record.Status != tt.logged[i] || record.Attempts != i+1 {
+					t.Errorf("Delivery log record %d = %s after %d attempts, want %s after %d", i, record.Status, record.Attempts, tt.logged[i], i+1)
+				}
+				statusCode := tt.statuses[len(tt.statuses)-1]
+				if i < len(tt.statuses) {
+					statusCode = tt.statuses[i]
+				}
+				if record.StatusCode != statusCode {
+					t.Errorf("Delivery log record %d status code = %d, want %d", i, record.StatusCode, statusCode)
+				}
+				if (record.Status == entity.DeliveryPending) != (record.NextAttempt != nil) {
+					t.Errorf("Delivery log record %d next attempt = %v with status %s", i, record.NextAttempt, record.Status)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatcherResumesPendingDeliveries(t *testing.T) {
+	webhook := newTestWebhook(t, http.StatusOK)
+	store, dir := newTestStore(t, webhook.server.URL)
+
+	event := testEvent()
+	body, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending := &entity.PendingDelivery{
+		Delivery: &entity.Delivery{
+			Id:        event.Id,
+			Watchlist: event.Watchlist,
+			Type:      event.Type,
+			Status:    entity.DeliveryPending,
+			Attempts:  1,
+			Created:   event.Time,
+			Updated:   event.Time,
+		},
+		Body: body,
+	}
+	if err := store.SetHeight("mainnet", 100, []*entity.PendingDelivery{pending}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The store is read again as it would be after a restart
+	store, err = NewFileStore(filepath.Join(dir, "watchlists.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height, _ := store.Height("mainnet"); height != 100 {
+		t.Fatalf("Height = %d, want 100", height)
+	}
+
+	d := newTestDispatcher(t, store, dir, 3)
+	delivery := waitForDelivery(t, d)
+	if delivery.Status != entity.DeliveryDelivered || delivery.Attempts != 2 {
+		t.Errorf("Delivery = %s after %d attempts, want delivered after 2", delivery.Status, delivery.Attempts)
+	}
+	if _, received := webhook.request(0); string(received) != string(body) {
+		t.Errorf("Webhook body = %s, want %s", received, body)
+	}
+	if pending := store.Pending(); len(pending) != 0 {
+		t.Errorf("Store has %d pending deliveries, want none", len(pending))
+	}
+}
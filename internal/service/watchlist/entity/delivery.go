@@ -0,0 +1,52 @@
+package entity
+
+import (
+	daoEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/entity"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"time"
+)
+
+type DeliveryStatus string
+
+var (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Event is the body posted to the watchlist url
+type Event struct {
+	Id        string                   `json:"id"`
+	Type      EventType                `json:"type"`
+	Network   string                   `json:"network"`
+	Watchlist string                   `json:"watchlist"`
+	Address   string                   `json:"address,omitempty"`
+	Time      time.Time                `json:"time"`
+	History   *explorer.AddressHistory `json:"history,omitempty"`
+	Dao       *daoEntity.DaoEvent      `json:"dao,omitempty"`
+}
+
+// Delivery is the state of an event's delivery after its latest attempt
+type Delivery struct {
+	Id          string         `json:"id"`
+	Watchlist   string         `json:"watchlist"`
+	Type        EventType      `json:"type"`
+	Address     string         `json:"address,omitempty"`
+	Height      uint64         `json:"height,omitempty"`
+	TxId        string         `json:"txid,omitempty"`
+	Hash        string         `json:"hash,omitempty"`
+	Status      DeliveryStatus `json:"status"`
+	Attempts    int            `json:"attempts"`
+	StatusCode  int            `json:"statusCode,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	Created     time.Time      `json:"created"`
+	Updated     time.Time      `json:"updated"`
+	NextAttempt *time.Time     `json:"nextAttempt,omitempty"`
+}
+
+// PendingDelivery is a delivery which is queued or waiting to be retried, with the body of its event
+// kept byte for byte so a resumed delivery is signed over the same body
+type PendingDelivery struct {
+	Delivery *Delivery `json:"delivery"`
+	Body     []byte    `json:"body"`
+}
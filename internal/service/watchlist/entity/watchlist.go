@@ -0,0 +1,73 @@
+package entity
+
+import (
+	daoEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/entity"
+	"time"
+)
+
+type EventType string
+
+var (
+	EventReceive EventType = "receive"
+	EventSend    EventType = "send"
+	EventStake   EventType = "stake"
+	EventDao     EventType = "dao"
+	EventPing    EventType = "ping"
+)
+
+var EventTypes = []EventType{EventReceive, EventSend, EventStake, EventDao}
+
+// Watchlist delivers the new history of its addresses to Url, signed with Secret.
+// With the dao event it also delivers the DAO events of the followed proposal, payment request and consultation hashes,
+// or of every one when none are followed. The secret is only returned when the watchlist is created.
+type Watchlist struct {
+	Id        string      `json:"id"`
+	Owner     string      `json:"owner"`
+	Network   string      `json:"network"`
+	Url       string      `json:"url"`
+	Secret    string      `json:"secret,omitempty"`
+	Events    []EventType `json:"events"`
+	Addresses []string    `json:"addresses"`
+	Follow    []string    `json:"follow,omitempty"`
+	Created   time.Time   `json:"created"`
+	Updated   time.Time   `json:"updated"`
+}
+
+// HasEvent reports whether the watchlist delivers the event type, every address event when no events are set
+func (w *Watchlist) HasEvent(eventType EventType) bool {
+	if eventType == EventPing {
+		return true
+	}
+	if len(w.Events) == 0 {
+		return eventType != EventDao
+	}
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithoutSecret is a copy of the watchlist safe to return
+func (w *Watchlist) WithoutSecret() *Watchlist {
+	watchlist := *w
+	watchlist.Secret = ""
+
+	return &watchlist
+}
+
+// Follows reports whether the watchlist delivers the DAO event, a payment request is followed through its proposal
+func (w *Watchlist) Follows(event *daoEntity.DaoEvent) bool {
+	if len(w.Follow) == 0 {
+		return true
+	}
+	for _, hash := range w.Follow {
+		if hash == event.Hash || (event.ProposalHash != "" && hash == event.ProposalHash) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,17 @@
+package entity
+
+type WatchlistRequest struct {
+	Url       string      `json:"url"`
+	Events    []EventType `json:"events"`
+	Addresses []string    `json:"addresses"`
+	Follow    []string    `json:"follow"`
+}
+
+// WatchlistChange updates the url, events or followed hashes when set, and adds and removes addresses
+type WatchlistChange struct {
+	Url    *string      `json:"url"`
+	Events *[]EventType `json:"events"`
+	Follow *[]string    `json:"follow"`
+	Add    []string     `json:"add"`
+	Remove []string     `json:"remove"`
+}
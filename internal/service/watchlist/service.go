@@ -0,0 +1,508 @@
+package watchlist
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/address"
+	authEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/auth/entity"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/block"
+	daoEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/entity"
+	daoEvent "github.com/navcoin/navexplorer-api-go/v2/internal/service/dao/event"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/network"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist/entity"
+	"github.com/navcoin/navexplorer-indexer-go/v2/pkg/explorer"
+	"go.uber.org/zap"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MaxWatchlistAddresses = 1000
+	MaxWatchlistFollow    = 1000
+	// The most blocks searched for new history at once, after a longer pause only the latest block is searched
+	maxCatchUpBlocks uint64 = 1000
+)
+
+var (
+	ErrWatchlistUrlInvalid       = errors.New("Watchlist url must be an absolute http or https url")
+	ErrWatchlistUrlPrivate       = errors.New("Watchlist url must not be a local or private address")
+	ErrWatchlistEventInvalid     = errors.New("Watchlist events must be receive, send, stake or dao")
+	ErrWatchlistAddressInvalid   = errors.New("Watchlist address is not valid")
+	ErrWatchlistAddressesMissing = errors.New("Watchlist has no addresses and no dao event")
+	ErrWatchlistAddressesSize    = fmt.Errorf("A watchlist has at most %d addresses", MaxWatchlistAddresses)
+	ErrWatchlistFollowInvalid    = errors.New("Watchlist follow must be proposal, payment request or consultation hashes")
+	ErrWatchlistFollowSize       = fmt.Errorf("A watchlist follows at most %d hashes", MaxWatchlistFollow)
+)
+
+var daoHash = regexp.MustCompile("^[0-9a-f]{64}$")
+
+func IsWatchlistError(err error) bool {
+	return err == ErrWatchlistUrlInvalid ||
+		err == ErrWatchlistUrlPrivate ||
+		err == ErrWatchlistEventInvalid ||
+		errors.Is(err, ErrWatchlistAddressInvalid) ||
+		err == ErrWatchlistAddressesMissing ||
+		err == ErrWatchlistAddressesSize ||
+		errors.Is(err, ErrWatchlistFollowInvalid) ||
+		err == ErrWatchlistFollowSize
+}
+
+type Service interface {
+	Create(n network.Network, credential *authEntity.Credential, request *entity.WatchlistRequest) (*entity.Watchlist, error)
+	GetWatchlists(credential *authEntity.Credential) []*entity.Watchlist
+	GetWatchlist(credential *authEntity.Credential, id string) (*entity.Watchlist, error)
+	Update(credential *authEntity.Credential, id string, change *entity.WatchlistChange) (*entity.Watchlist, error)
+	Delete(credential *authEntity.Credential, id string) error
+	Ping(credential *authEntity.Credential, id string) (*entity.Delivery, error)
+	GetDeliveries(credential *authEntity.Credential, id string) ([]*entity.Delivery, error)
+	Close()
+}
+
+type service struct {
+	store                    Store
+	addressHistoryRepository repository.AddressHistoryRepository
+	dispatcher               *dispatcher
+	allowPrivate             bool
+	mu                       sync.Mutex
+}
+
+// NewWatchlistService delivers the new history of watched addresses as each block is published by the watcher,
+// and the DAO events of the watchlists with the dao event
+func NewWatchlistService(
+	store Store,
+	addressHistoryRepository repository.AddressHistoryRepository,
+	watcher block.Watcher,
+	daoEventService daoEvent.Service,
+	timeout time.Duration,
+	maxAttempts int,
+	backoff time.Duration,
+	workers int,
+	deliveryLogPath string,
+	allowPrivate bool,
+) Service {
+	s := &service{
+		store:                    store,
+		addressHistoryRepository: addressHistoryRepository,
+		allowPrivate:             allowPrivate,
+	}
+	s.dispatcher = newDispatcher(newWebhookClient(timeout, allowPrivate), maxAttempts, backoff, workers, deliveryLogPath, store)
+	watcher.OnBlock(s.onBlock)
+	daoEventService.OnEvent(s.onDaoEvent)
+
+	return s
+}
+
+func (s *service) Create(n network.Network, credential *authEntity.Credential, request *entity.WatchlistRequest) (*entity.Watchlist, error) {
+	if err := s.validateUrl(request.Url); err != nil {
+		return nil, err
+	}
+	if err := validateEvents(request.Events); err != nil {
+		return nil, err
+	}
+
+	addresses, err := mergeAddresses(n, nil, request.Addresses, nil)
+	if err != nil {
+		return nil, err
+	}
+	follow, err := validateFollow(request.Follow)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+
+	events := request.Events
+	if events == nil {
+		events = make([]entity.EventType, 0)
+	}
+
+	now := time.Now().UTC()
+	watchlist := &entity.Watchlist{
+		Id:        id,
+		Owner:     credential.Name,
+		Network:   n.Name,
+		Url:       request.Url,
+		Secret:    secret,
+		Events:    events,
+		Addresses: addresses,
+		Follow:    follow,
+		Created:   now,
+		Updated:   now,
+	}
+	if err := validateWatching(watchlist); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Put(watchlist); err != nil {
+		return nil, err
+	}
+	zap.L().With(zap.String("id", id), zap.String("owner", credential.Name)).Info("Watchlist: Created")
+
+	return watchlist, nil
+}
+
+// GetWatchlists returns the watchlists of the credential, or every watchlist for an admin
+func (s *service) GetWatchlists(credential *authEntity.Credential) []*entity.Watchlist {
+	watchlists := make([]*entity.Watchlist, 0)
+	for _, watchlist := range s.store.All() {
+		if canAccess(credential, watchlist) {
+			watchlists = append(watchlists, watchlist.WithoutSecret())
+		}
+	}
+
+	return watchlists
+}
+
+func (s *service) GetWatchlist(credential *authEntity.Credential, id string) (*entity.Watchlist, error) {
+	watchlist, err := s.getWatchlist(credential, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return watchlist.WithoutSecret(), nil
+}
+
+func (s *service) Update(credential *authEntity.Credential, id string, change *entity.WatchlistChange) (*entity.Watchlist, error) {
+	// Updates are applied one at a time so concurrent changes are not lost
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watchlist, err := s.getWatchlist(credential, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if change.Url != nil {
+		if err := s.validateUrl(*change.Url); err != nil {
+			return nil, err
+		}
+		watchlist.Url = *change.Url
+	}
+	if change.Events != nil {
+		if err := validateEvents(*change.Events); err != nil {
+			return nil, err
+		}
+		watchlist.Events = *change.Events
+		if watchlist.Events == nil {
+			watchlist.Events = make([]entity.EventType, 0)
+		}
+	}
+
+	if change.Follow != nil {
+		if watchlist.Follow, err = validateFollow(*change.Follow); err != nil {
+			return nil, err
+		}
+	}
+
+	n, err := network.GetNetwork(watchlist.Network)
+	if err != nil {
+		return nil, err
+	}
+	if watchlist.Addresses, err = mergeAddresses(n, watchlist.Addresses, change.Add, change.Remove); err != nil {
+		return nil, err
+	}
+	if err := validateWatching(watchlist); err != nil {
+		return nil, err
+	}
+	watchlist.Updated = time.Now().UTC()
+
+	if err := s.store.Put(watchlist); err != nil {
+		return nil, err
+	}
+
+	return watchlist.WithoutSecret(), nil
+}
+
+func (s *service) Delete(credential *authEntity.Credential, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.getWatchlist(credential, id); err != nil {
+		return err
+	}
+	if err := s.store.Delete(id); err != nil {
+		return err
+	}
+	s.dispatcher.forget(id)
+	zap.L().With(zap.String("id", id), zap.String("owner", credential.Name)).Info("Watchlist: Deleted")
+
+	return nil
+}
+
+// Ping delivers an event without history so the url and signature can be checked
+func (s *service) Ping(credential *authEntity.Credential, id string) (*entity.Delivery, error) {
+	watchlist, err := s.getWatchlist(credential, id)
+	if err != nil {
+		return nil, err
+	}
+
+	eventId, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dispatcher.dispatch(&entity.Event{
+		Id:        eventId,
+		Type:      entity.EventPing,
+		Network:   watchlist.Network,
+		Watchlist: watchlist.Id,
+		Time:      time.Now().UTC(),
+	})
+}
+
+func (s *service) GetDeliveries(credential *authEntity.Credential, id string) ([]*entity.Delivery, error) {
+	if _, err := s.getWatchlist(credential, id); err != nil {
+		return nil, err
+	}
+
+	return s.dispatcher.deliveries(id), nil
+}
+
+func (s *service) Close() {
+	s.dispatcher.close()
+}
+
+// getWatchlist hides the watchlists of other credentials as not found
+func (s *service) getWatchlist(credential *authEntity.Credential, id string) (*entity.Watchlist, error) {
+	watchlist, err := s.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess(credential, watchlist) {
+		return nil, ErrWatchlistNotFound
+	}
+
+	return watchlist, nil
+}
+
+// onBlock searches the history of the watched addresses since the last height delivered on the network,
+// which only advances once the history is searched so a failed search is retried with the next block
+func (s *service) onBlock(n network.Network, block *explorer.Block) {
+	last, seen := s.store.Height(n.Name)
+
+	from := block.Height - 1
+	if seen && last < block.Height && block.Height-last <= maxCatchUpBlocks {
+		from = last
+	}
+
+	watched := make(map[string][]*entity.Watchlist)
+	for _, watchlist := range s.store.All() {
+		if watchlist.Network != n.Name {
+			continue
+		}
+		for _, hash := range watchlist.Addresses {
+			watched[hash] = append(watched[hash], watchlist)
+		}
+	}
+	if len(watched) == 0 {
+		s.setHeight(n, block.Height, nil)
+		return
+	}
+
+	addresses := make([]string, 0, len(watched))
+	for hash := range watched {
+		addresses = append(addresses, hash)
+	}
+
+	pending := make([]*entity.PendingDelivery, 0)
+	err := s.addressHistoryRepository.GetHistoryForAddresses(n, from, block.Height, addresses, func(history *explorer.AddressHistory) error {
+		eventType := historyEventType(history)
+		for _, watchlist := range watched[history.Hash] {
+			if !watchlist.HasEvent(eventType) {
+				continue
+			}
+
+			delivery, err := s.dispatcher.prepare(&entity.Event{
+				Id:        eventId(watchlist.Id, n.Name, history.TxId, history.Hash),
+				Type:      eventType,
+				Network:   n.Name,
+				Watchlist: watchlist.Id,
+				Address:   history.Hash,
+				Time:      time.Now().UTC(),
+				History:   history,
+			})
+			if err != nil {
+				return err
+			}
+			pending = append(pending, delivery)
+		}
+
+		return nil
+	})
+	if err != nil {
+		// The height is kept so the next block searches these blocks again
+		zap.L().With(zap.Error(err), zap.String("network", n.String()), zap.Uint64("height", block.Height)).
+			Error("Watchlist: Failed to get history of watched addresses")
+		return
+	}
+
+	// The deliveries are only queued once they are saved with the height, so a restart never skips them
+	if s.setHeight(n, block.Height, pending) {
+		for _, delivery := range pending {
+			s.dispatcher.start(delivery)
+		}
+	}
+}
+
+func (s *service) setHeight(n network.Network, height uint64, pending []*entity.PendingDelivery) bool {
+	if err := s.store.SetHeight(n.Name, height, pending); err != nil {
+		zap.L().With(zap.Error(err), zap.String("network", n.String()), zap.Uint64("height", height)).
+			Error("Watchlist: Failed to save the delivered height")
+		return false
+	}
+
+	return true
+}
+
+// onDaoEvent delivers the DAO event to the watchlists of the network that follow it
+func (s *service) onDaoEvent(n network.Network, event *daoEntity.DaoEvent) {
+	for _, watchlist := range s.store.All() {
+		if watchlist.Network != n.Name || !watchlist.HasEvent(entity.EventDao) || !watchlist.Follows(event) {
+			continue
+		}
+
+		_, err := s.dispatcher.dispatch(&entity.Event{
+			Id:        eventId(watchlist.Id, n.Name, "dao", strconv.FormatUint(event.Id, 10)),
+			Type:      entity.EventDao,
+			Network:   n.Name,
+			Watchlist: watchlist.Id,
+			Time:      time.Now().UTC(),
+			Dao:       event,
+		})
+		if err != nil {
+			zap.L().With(zap.Error(err), zap.String("id", watchlist.Id), zap.String("hash", event.Hash)).
+				Error("Watchlist: Failed to dispatch DAO event")
+		}
+	}
+}
+
+func historyEventType(history *explorer.AddressHistory) entity.EventType {
+	if history.Stake {
+		return entity.EventStake
+	}
+	if history.Changes.Spendable > 0 || history.Changes.Stakable > 0 {
+		return entity.EventReceive
+	}
+
+	return entity.EventSend
+}
+
+func canAccess(credential *authEntity.Credential, watchlist *entity.Watchlist) bool {
+	return watchlist.Owner == credential.Name || credential.HasRole(authEntity.RoleAdmin)
+}
+
+func (s *service) validateUrl(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrWatchlistUrlInvalid
+	}
+	if !s.allowPrivate && isPrivateHost(u) {
+		return ErrWatchlistUrlPrivate
+	}
+
+	return nil
+}
+
+func validateEvents(events []entity.EventType) error {
+	for _, event := range events {
+		valid := false
+		for _, eventType := range entity.EventTypes {
+			valid = valid || event == eventType
+		}
+		if !valid {
+			return ErrWatchlistEventInvalid
+		}
+	}
+
+	return nil
+}
+
+// mergeAddresses adds and then removes addresses, keeping the order they were first added in
+func mergeAddresses(n network.Network, addresses []string, add []string, remove []string) ([]string, error) {
+	removed := make(map[string]bool)
+	for _, hash := range remove {
+		removed[hash] = true
+	}
+
+	merged := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, hash := range append(append([]string{}, addresses...), add...) {
+		if seen[hash] || removed[hash] {
+			continue
+		}
+		if validation := address.Validate(n.Name, hash); !validation.Valid {
+			return nil, fmt.Errorf("%w: %s", ErrWatchlistAddressInvalid, hash)
+		}
+		seen[hash] = true
+		merged = append(merged, hash)
+	}
+
+	if len(merged) > MaxWatchlistAddresses {
+		return nil, ErrWatchlistAddressesSize
+	}
+
+	return merged, nil
+}
+
+// validateFollow lowercases and dedupes the followed hashes
+func validateFollow(hashes []string) ([]string, error) {
+	follow := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, hash := range hashes {
+		hash = strings.ToLower(hash)
+		if !daoHash.MatchString(hash) {
+			return nil, fmt.Errorf("%w: %s", ErrWatchlistFollowInvalid, hash)
+		}
+		if !seen[hash] {
+			seen[hash] = true
+			follow = append(follow, hash)
+		}
+	}
+
+	if len(follow) > MaxWatchlistFollow {
+		return nil, ErrWatchlistFollowSize
+	}
+
+	return follow, nil
+}
+
+// validateWatching requires addresses unless the watchlist only delivers DAO events
+func validateWatching(watchlist *entity.Watchlist) error {
+	if len(watchlist.Addresses) == 0 && !watchlist.HasEvent(entity.EventDao) {
+		return ErrWatchlistAddressesMissing
+	}
+
+	return nil
+}
+
+// eventId derives the id of an event from what it is about, so an event delivered again has the same id
+// and its receiver can ignore the repeat
+func eventId(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+
+	return hex.EncodeToString(sum[:16])
+}
+
+func randomHex(length int) (string, error) {
+	bytes := make([]byte, length)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,301 @@
+package watchlist
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/service/watchlist/entity"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+var ErrWatchlistNotFound = errors.New("Watchlist not found")
+
+type Store interface {
+	All() []*entity.Watchlist
+	Get(id string) (*entity.Watchlist, error)
+	Put(watchlist *entity.Watchlist) error
+	Delete(id string) error
+	// Height is the last height of the network whose history was delivered
+	Height(network string) (uint64, bool)
+	// SetHeight advances the delivered height of the network and adds the deliveries found up to it in one write,
+	// so the height never passes history whose deliveries are not saved
+	SetHeight(network string, height uint64, pending []*entity.PendingDelivery) error
+	// Pending is the deliveries which have not been delivered or failed, oldest first
+	Pending() []*entity.PendingDelivery
+	// PutPending saves a delivery which is queued or waiting to be retried
+	PutPending(pending *entity.PendingDelivery) error
+	// DeletePending removes a delivery once it was delivered or failed
+	DeletePending(id string) error
+}
+
+type fileStore struct {
+	path       string
+	watchlists map[string]*entity.Watchlist
+	heights    map[string]uint64
+	pending    map[string]*entity.PendingDelivery
+	mu         sync.RWMutex
+}
+
+type storeFile struct {
+	Watchlists []*entity.Watchlist       `json:"watchlists"`
+	Heights    map[string]uint64         `json:"heights"`
+	Pending    []*entity.PendingDelivery `json:"pending"`
+}
+
+// NewFileStore keeps the watchlists, the delivered heights and the pending deliveries in the JSON file at path,
+// a missing file has no watchlists
+func NewFileStore(path string) (Store, error) {
+	s := &fileStore{
+		path:       path,
+		watchlists: make(map[string]*entity.Watchlist),
+		heights:    make(map[string]uint64),
+		pending:    make(map[string]*entity.PendingDelivery),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	file := &storeFile{}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	for _, watchlist := range file.Watchlists {
+		s.watchlists[watchlist.Id] = watchlist
+	}
+	for network, height := range file.Heights {
+		s.heights[network] = height
+	}
+	for _, pending := range file.Pending {
+		s.pending[pending.Delivery.Id] = pending
+	}
+
+	return s, nil
+}
+
+// All returns copies of the watchlists, oldest first
+func (s *fileStore) All() []*entity.Watchlist {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sorted()
+}
+
+func (s *fileStore) Get(id string) (*entity.Watchlist, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	watchlist, ok := s.watchlists[id]
+	if !ok {
+		return nil, ErrWatchlistNotFound
+	}
+	w := *watchlist
+
+	return &w, nil
+}
+
+func (s *fileStore) Put(watchlist *entity.Watchlist) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.watchlists[watchlist.Id]
+	w := *watchlist
+	s.watchlists[watchlist.Id] = &w
+
+	if err := s.save(); err != nil {
+		if existed {
+			s.watchlists[watchlist.Id] = previous
+		} else {
+			delete(s.watchlists, watchlist.Id)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *fileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.watchlists[id]
+	if !ok {
+		return ErrWatchlistNotFound
+	}
+	delete(s.watchlists, id)
+
+	if err := s.save(); err != nil {
+		s.watchlists[id] = previous
+		return err
+	}
+
+	return nil
+}
+
+func (s *fileStore) Height(network string) (uint64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	height, ok := s.heights[network]
+
+	return height, ok
+}
+
+func (s *fileStore) SetHeight(network string, height uint64, pending []*entity.PendingDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previousHeight, existed := s.heights[network]
+	previousPending := make(map[string]*entity.PendingDelivery, len(s.pending))
+	for id, p := range s.pending {
+		previousPending[id] = p
+	}
+
+	s.heights[network] = height
+	for _, p := range pending {
+		// A delivery searched for again keeps the attempts already made
+		if _, ok := s.pending[p.Delivery.Id]; !ok {
+			s.pending[p.Delivery.Id] = copyPending(p)
+		}
+	}
+
+	if err := s.save(); err != nil {
+		if existed {
+			s.heights[network] = previousHeight
+		} else {
+			delete(s.heights, network)
+		}
+		s.pending = previousPending
+		return err
+	}
+
+	return nil
+}
+
+func (s *fileStore) Pending() []*entity.PendingDelivery {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pending := make([]*entity.PendingDelivery, 0, len(s.pending))
+	for _, p := range s.sortedPending() {
+		pending = append(pending, copyPending(p))
+	}
+
+	return pending
+}
+
+func (s *fileStore) PutPending(pending *entity.PendingDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := pending.Delivery.Id
+	previous, existed := s.pending[id]
+	s.pending[id] = copyPending(pending)
+
+	if err := s.save(); err != nil {
+		if existed {
+			s.pending[id] = previous
+		} else {
+			delete(s.pending, id)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *fileStore) DeletePending(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.pending[id]
+	if !ok {
+		return nil
+	}
+	delete(s.pending, id)
+
+	if err := s.save(); err != nil {
+		s.pending[id] = previous
+		return err
+	}
+
+	return nil
+}
+
+// copyPending copies the delivery state, which the dispatcher changes with each attempt
+func copyPending(pending *entity.PendingDelivery) *entity.PendingDelivery {
+	delivery := *pending.Delivery
+
+	return &entity.PendingDelivery{Delivery: &delivery, Body: pending.Body}
+}
+
+func (s *fileStore) sorted() []*entity.Watchlist {
+	watchlists := make([]*entity.Watchlist, 0, len(s.watchlists))
+	for _, watchlist := range s.watchlists {
+		w := *watchlist
+		watchlists = append(watchlists, &w)
+	}
+	sort.Slice(watchlists, func(i, j int) bool {
+		if !watchlists[i].Created.Equal(watchlists[j].Created) {
+			return watchlists[i].Created.Before(watchlists[j].Created)
+		}
+		return watchlists[i].Id < watchlists[j].Id
+	})
+
+	return watchlists
+}
+
+func (s *fileStore) sortedPending() []*entity.PendingDelivery {
+	pending := make([]*entity.PendingDelivery, 0, len(s.pending))
+	for _, p := range s.pending {
+		pending = append(pending, p)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if !pending[i].Delivery.Created.Equal(pending[j].Delivery.Created) {
+			return pending[i].Delivery.Created.Before(pending[j].Delivery.Created)
+		}
+		return pending[i].Delivery.Id < pending[j].Delivery.Id
+	})
+
+	return pending
+}
+
+// save writes to a temporary file that replaces the store so a failed write never leaves it truncated
+func (s *fileStore) save() error {
+	data, err := json.MarshalIndent(&storeFile{Watchlists: s.sorted(), Heights: s.heights, Pending: s.sortedPending()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0600); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	return os.Rename(file.Name(), s.path)
+}
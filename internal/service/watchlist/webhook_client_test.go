@@ -0,0 +1,103 @@
+package watchlist
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestPublicOnly(t *testing.T) {
+	tests := []struct {
+		address string
+		blocked bool
+	}{
+		{address: "127.0.0.1:80", blocked: true},
+		{address: "10.1.2.3:443", blocked: true},
+		{address: "172.16.0.1:80", blocked: true},
+		{address: "192.168.1.1:80", blocked: true},
+		{address: "169.254.169.254:80", blocked: true},
+		{address: "100.64.0.1:80", blocked: true},
+		{address: "0.0.0.0:80", blocked: true},
+		{address: "[::1]:80", blocked: true},
+		{address: "[fd00::1]:80", blocked: true},
+		{address: "[fe80::1]:80", blocked: true},
+		{address: "[::ffff:127.0.0.1]:80", blocked: true},
+		{address: "93.184.216.34:443"},
+		{address: "[2606:2800:220:1:248:1893:25c8:1946]:443"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := publicOnly("tcp", tt.address, nil)
+			if blocked := errors.Is(err, ErrWebhookAddressBlocked); blocked != tt.blocked {
+				t.Errorf("publicOnly(%s) = %v, want blocked %v", tt.address, err, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestIsPrivateHost(t *testing.T) {
+	tests := []struct {
+		url     string
+		private bool
+	}{
+		{url: "http://localhost:9000", private: true},
+		{url: "http://api.localhost/hook", private: true},
+		{url: "http://127.0.0.1/hook", private: true},
+		{url: "http://[::1]/hook", private: true},
+		{url: "http://169.254.169.254/latest/meta-data", private: true},
+		{url: "https://example.com/hook"},
+		{url: "https://93.184.216.34/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if private := isPrivateHost(u); private != tt.private {
+				t.Errorf("isPrivateHost(%s) = %v, want %v", tt.url, private, tt.private)
+			}
+		})
+	}
+}
+
+func TestWebhookClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/hook", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// The test server listens on loopback, so it is refused unless private addresses are allowed
+	if _, err := newWebhookClient(time.Second, false).Get(server.URL + "/hook"); !errors.Is(err, ErrWebhookAddressBlocked) {
+		t.Errorf("Get() error = %v, want %v", err, ErrWebhookAddressBlocked)
+	}
+
+	client := newWebhookClient(time.Second, true)
+	response, err := client.Get(server.URL + "/hook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusNoContent {
+		t.Errorf("StatusCode = %d, want %d", response.StatusCode, http.StatusNoContent)
+	}
+
+	response, err = client.Get(server.URL + "/redirect")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusFound {
+		t.Errorf("StatusCode = %d, want the redirect %d", response.StatusCode, http.StatusFound)
+	}
+}
+
@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/navcoin/navexplorer-api-go/v2/generated/dic"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/config"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/framework"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/graph"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/metrics"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/ratelimit"
+	"github.com/navcoin/navexplorer-api-go/v2/internal/repository"
 	"github.com/navcoin/navexplorer-api-go/v2/internal/resource"
+	authEntity "github.com/navcoin/navexplorer-api-go/v2/internal/service/auth/entity"
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 var container *dic.Container
@@ -26,12 +35,16 @@ func main() {
 	framework.SetReleaseMode(config.Get().Debug)
 
 	r := gin.New()
+	r.Use(framework.Metrics())
 	r.Use(gzip.Gzip(gzip.DefaultCompression))
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
 	r.Use(framework.Cors())
 	r.Use(framework.NetworkSelect)
 	r.Use(framework.Options)
+	if config.Get().RateLimit.Enabled {
+		r.Use(framework.RateLimit(ratelimit.NewLimiter(), config.Get().RateLimit, container.GetAuthService()))
+	}
 	r.Use(framework.ErrorHandler)
 	r.Use(framework.RR())
 
@@ -39,35 +52,53 @@ func main() {
 		c.String(http.StatusOK, "Welcome to NavExplorer API!")
 	})
 
-	authorized := r.Group("/auth", gin.BasicAuth(config.Account()))
+	healthResource := resource.NewHealthResource(container.GetHealthService())
+	r.GET("/health/live", healthResource.GetLive)
+	r.GET("/health/ready", healthResource.GetReady)
+
+	metrics.Register(metrics.NewCacheCollector(container.GetCache()))
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	openApiResource := resource.NewOpenApiResource()
+	r.GET("/openapi.json", openApiResource.GetSpecification)
+
+	metaEditor := r.Group("/auth", framework.RequireRole(container.GetAuthService(), authEntity.RoleMetaEditor), framework.Audit(container.GetAuditLog()))
 
 	addressResource := resource.NewAddressResource(container.GetAddressService(), container.GetCache())
-	r.GET("/address", addressResource.GetAddresses)
+	r.GET("/address", framework.Filterable(repository.AddressFilters), addressResource.GetAddresses)
+	r.GET("/address/labels", addressResource.GetMetaAddresses)
 	r.GET("/address/:hash", addressResource.GetAddress)
+	r.GET("/address/:hash/meta", addressResource.GetAddressMeta)
 	r.GET("/address/:hash/summary", addressResource.GetSummary)
-	r.GET("/address/:hash/history", addressResource.GetHistory)
+	r.GET("/address/:hash/history", framework.Filterable(repository.AddressHistoryFilters), addressResource.GetHistory)
+	r.GET("/address/:hash/history/export", framework.Filterable(repository.AddressHistoryFilters), framework.WriteTimeout(config.Get().Server.ExportTimeout), addressResource.ExportHistory)
+	r.GET("/address/:hash/balance", addressResource.GetBalance)
 	r.GET("/address/:hash/validate", addressResource.ValidateAddress)
 	r.GET("/address/:hash/staking", addressResource.GetStakingChart)
+	r.GET("/address/:hash/staking/report", addressResource.GetStakingReport)
 	r.GET("/address/:hash/assoc/staking", addressResource.GetAssociatedStakingAddresses)
 	r.GET("/balance", addressResource.GetBalancesForAddresses)
 	r.GET("/addressgroup", addressResource.GetAddressGroups)
 	r.GET("/addresses", addressResource.GetAddressGroupsTotal)
-	authorized.PUT("/address/:hash/meta", addressResource.PutAddressMeta)
+	metaEditor.PUT("/address/:hash/meta", addressResource.PutAddressMeta)
+	metaEditor.PATCH("/address/:hash/meta", addressResource.UpdateAddressMeta)
+	metaEditor.DELETE("/address/:hash/meta/:key", addressResource.DeleteAddressMeta)
+	metaEditor.POST("/address/meta/import", addressResource.ImportAddressMeta)
 
 	distributionResource := resource.NewDistributionResource(container.GetAddressService(), container.GetBlockService())
 	r.GET("/distribution/supply", distributionResource.GetSupply)
 	r.GET("/distribution/wealth", distributionResource.GetWealth)
 
-	blockResource := resource.NewBlockResource(container.GetBlockService(), container.GetDaoService(), container.GetCache())
+	blockResource := resource.NewBlockResource(container.GetBlockService(), container.GetDaoService(), container.GetAddressService(), container.GetCache())
 	r.GET("/bestblock", blockResource.GetBestBlock)
 	r.GET("/blockcycle", blockResource.GetBestBlockCycle)
 	r.GET("/blockgroup", blockResource.GetBlockGroups)
-	r.GET("/block", blockResource.GetBlocks)
+	r.GET("/block", framework.Filterable(repository.BlockFilters), blockResource.GetBlocks)
 	r.GET("/block/:hash", blockResource.GetBlock)
 	r.GET("/block/:hash/cycle", blockResource.GetBlockCycle)
 	r.GET("/block/:hash/raw", blockResource.GetRawBlock)
 	r.GET("/block/:hash/tx", blockResource.GetTransactionsByBlock)
-	r.GET("/tx", blockResource.GetTransactions)
+	r.GET("/tx", framework.Filterable(repository.TransactionFilters), blockResource.GetTransactions)
 	r.GET("/tx/:hash", blockResource.GetTransactionByHash)
 	r.GET("/tx/:hash/raw", blockResource.GetRawTransactionByHash)
 	r.GET("/txcount", blockResource.CountTransactions)
@@ -75,6 +106,8 @@ func main() {
 	stakingResource := resource.NewStakingResource(container.GetAddressService(), container.GetStakingService())
 	r.GET("/staking/blocks", stakingResource.GetBlocks)
 	r.GET("/staking/rewards", stakingResource.GetStakingRewardsForAddresses)
+	r.GET("/staking/estimate", stakingResource.GetEstimate)
+	r.GET("/staking/leaderboard", stakingResource.GetLeaderboard)
 
 	softForkResource := resource.NewSoftForkResource(container.GetSoftforkService(), container.GetSoftforkRepo())
 	r.GET("/softfork", softForkResource.GetSoftForks)
@@ -89,6 +122,9 @@ func main() {
 	daoGroup.GET("/answer/:hash", daoResource.GetAnswer)
 	daoGroup.GET("/consultation/:hash/:answer/votes", daoResource.GetAnswerVotes)
 
+	daoEventResource := resource.NewDaoEventResource(container.GetDaoEventService())
+	daoGroup.GET("/events", daoEventResource.GetEvents)
+
 	cfundGroup := daoGroup.Group("/cfund")
 	cfundGroup.GET("/stats", daoResource.GetCfundStats)
 	cfundGroup.GET("/proposal", daoResource.GetProposals)
@@ -102,15 +138,144 @@ func main() {
 	cfundGroup.GET("/payment-request/:hash/trend", daoResource.GetPaymentRequestTrend)
 	cfundGroup.GET("/votes/excluded", daoResource.GetExcludedVotesForCycle)
 
-	searchResource := resource.NewSearchResource(container.GetAddressService(), container.GetBlockService(), container.GetDaoService())
+	searchResource := resource.NewSearchResource(container.GetAddressService(), container.GetBlockService(), container.GetDaoService(), container.GetSearchService())
 	r.GET("/search", searchResource.Search)
+	r.GET("/search/suggest", searchResource.Suggest)
+
+	graphSchema, err := graph.NewSchema(container.GetBlockService(), container.GetAddressService(), container.GetDaoService(), container.GetSoftforkService())
+	if err != nil {
+		log.WithError(err).Fatal("Failed to create the GraphQL schema")
+	}
+	graphQLResource := resource.NewGraphQLResource(graphSchema)
+	r.GET("/graphql", graphQLResource.Query)
+	r.POST("/graphql", graphQLResource.Query)
 
 	supplyResource := resource.NewSupplyResource(container.GetBlockService(), container.GetDaoConsensusService())
 	r.GET("/supply", supplyResource.GetSupply)
 
+	if config.Get().Feed.BaseUrl == "" {
+		log.Warn("FEED_BASE_URL is not set, feeds are not available")
+	}
+	feedResource := resource.NewFeedResource(container.GetFeedService())
+	r.GET("/feed/blocks", feedResource.GetBlocks)
+	r.GET("/feed/proposals", feedResource.GetProposals)
+	r.GET("/feed/payment-requests", feedResource.GetPaymentRequests)
+	r.GET("/feed/consultations", feedResource.GetConsultations)
+
+	insightResource := resource.NewInsightResource(container.GetInsightService())
+	insightApi := r.Group("/insight-api")
+	insightApi.GET("/status", insightResource.GetStatus)
+	insightApi.GET("/sync", insightResource.GetSync)
+	insightApi.GET("/block/:hash", insightResource.GetBlock)
+	insightApi.GET("/block-index/:height", insightResource.GetBlockIndex)
+	insightApi.GET("/blocks", insightResource.GetBlocks)
+	insightApi.GET("/tx/:txid", insightResource.GetTransaction)
+	insightApi.GET("/rawtx/:txid", insightResource.GetRawTransaction)
+	insightApi.GET("/txs", insightResource.GetTransactions)
+	insightApi.GET("/addr/:addr", insightResource.GetAddress)
+	insightApi.GET("/addr/:addr/balance", insightResource.GetBalance)
+	insightApi.GET("/addr/:addr/totalReceived", insightResource.GetTotalReceived)
+	insightApi.GET("/addr/:addr/totalSent", insightResource.GetTotalSent)
+	insightApi.GET("/addr/:addr/unconfirmedBalance", insightResource.GetUnconfirmedBalance)
+	insightApi.GET("/addr/:addr/utxo", insightResource.GetUnspentOutputs)
+	insightApi.GET("/addrs/:addrs/utxo", insightResource.GetUnspentOutputsForAddresses)
+	insightApi.POST("/addrs/utxo", insightResource.GetUnspentOutputsForAddresses)
+
+	esploraResource := resource.NewEsploraResource(container.GetEsploraService())
+	esploraApi := r.Group("/esplora-api")
+	esploraApi.GET("/blocks", esploraResource.GetBlocks)
+	esploraApi.GET("/blocks/:start_height", esploraResource.GetBlocks)
+	esploraApi.GET("/blocks/tip/height", esploraResource.GetTipHeight)
+	esploraApi.GET("/blocks/tip/hash", esploraResource.GetTipHash)
+	esploraApi.GET("/block-height/:height", esploraResource.GetBlockHash)
+	esploraApi.GET("/block/:hash", esploraResource.GetBlock)
+	esploraApi.GET("/block/:hash/status", esploraResource.GetBlockStatus)
+	esploraApi.GET("/block/:hash/txids", esploraResource.GetBlockTxids)
+	esploraApi.GET("/block/:hash/txid/:index", esploraResource.GetBlockTxid)
+	esploraApi.GET("/block/:hash/txs", esploraResource.GetBlockTransactions)
+	esploraApi.GET("/block/:hash/txs/:start_index", esploraResource.GetBlockTransactions)
+	esploraApi.GET("/tx/:txid", esploraResource.GetTransaction)
+	esploraApi.GET("/tx/:txid/status", esploraResource.GetTransactionStatus)
+	esploraApi.GET("/tx/:txid/hex", esploraResource.GetTransactionHex)
+	esploraApi.GET("/tx/:txid/outspends", esploraResource.GetOutspends)
+	esploraApi.GET("/tx/:txid/outspend/:vout", esploraResource.GetOutspend)
+	esploraApi.GET("/address/:addr", esploraResource.GetAddress)
+	esploraApi.GET("/address/:addr/txs", esploraResource.GetAddressTransactions)
+	esploraApi.GET("/address/:addr/txs/chain", esploraResource.GetAddressTransactions)
+	esploraApi.GET("/address/:addr/txs/chain/:last_seen", esploraResource.GetAddressTransactions)
+	esploraApi.GET("/address/:addr/txs/mempool", esploraResource.GetAddressMempoolTransactions)
+	esploraApi.GET("/address/:addr/utxo", esploraResource.GetUnspentOutputs)
+
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", config.Get().Server.Port),
+		Handler:      r,
+		ReadTimeout:  config.Get().Server.ReadTimeout,
+		WriteTimeout: config.Get().Server.WriteTimeout,
+		IdleTimeout:  config.Get().Server.IdleTimeout,
+		ConnContext:  framework.ConnContext,
+	}
+
+	if config.Get().Watchlist.Enabled {
+		watchlistResource := resource.NewWatchlistResource(container.GetWatchlistService())
+		watchlists := r.Group("/auth/watchlist", framework.RequireRole(container.GetAuthService(), authEntity.RoleWatchlist))
+		watchlists.POST("", watchlistResource.CreateWatchlist)
+		watchlists.GET("", watchlistResource.GetWatchlists)
+		watchlists.GET("/:id", watchlistResource.GetWatchlist)
+		watchlists.PATCH("/:id", watchlistResource.UpdateWatchlist)
+		watchlists.DELETE("/:id", watchlistResource.DeleteWatchlist)
+		watchlists.POST("/:id/ping", watchlistResource.PingWatchlist)
+		watchlists.GET("/:id/deliveries", watchlistResource.GetDeliveries)
+	}
+
+	if config.Get().Subscribe {
+		streamResource := resource.NewStreamResource(container.GetStreamService())
+		r.GET("/stream/blocks", streamResource.GetBlocks)
+
+		// Websocket connections are hijacked so Shutdown does not wait for them
+		server.RegisterOnShutdown(container.GetStreamService().Close)
+	}
+
+	if err := openApiResource.Generate(r.Routes()); err != nil {
+		log.WithError(err).Warn("The OpenAPI specification is incomplete")
+	}
+
+	container.GetBlockWatcher().Start()
+
 	r.NoRoute(func(c *gin.Context) {
 		c.JSON(404, gin.H{"code": 404, "message": "Resource not found"})
 	})
 
-	_ = r.Run(fmt.Sprintf(":%d", config.Get().Server.Port))
+	go func() {
+		log.Infof("Listening on %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Fatal("Failed to start the server")
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+
+	log.Infof("Received %s, shutting down", sig)
+	shutdown(server)
+}
+
+// shutdown stops the block watcher so no handler is called while the streams close,
+// drains in-flight requests within the shutdown timeout and then stops the background workers
+func shutdown(server *http.Server) {
+	container.GetBlockWatcher().Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Get().Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.WithError(err).Error("Failed to drain requests before the shutdown timeout")
+	}
+
+	if config.Get().Watchlist.Enabled {
+		container.GetWatchlistService().Close()
+	}
+	container.GetCache().Stop()
+
+	log.Info("Shutdown complete")
 }